@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+
+	"example.com/go-slice-patterns-workload/slicepat"
 )
 
 type DTO struct {
@@ -15,33 +17,25 @@ type DTO struct {
 }
 
 // ---- データ生成
-func genUsers(n int) []User {
-	us := make([]User, n)
-	for i := 0; i < n; i++ {
-		us[i] = User{
-			ID:    uint(i + 1),
-			Name:  "User_" + strconv.Itoa(i),
-			Age:   uint(18 + (i % 50)),
-			Email: "user" + strconv.Itoa(i) + "@example.com",
-			City:  "City" + strconv.Itoa(i%10),
-		}
+func newUser(i int) User {
+	return User{
+		ID:    uint(i + 1),
+		Name:  "User_" + strconv.Itoa(i),
+		Age:   uint(18 + (i % 50)),
+		Email: "user" + strconv.Itoa(i) + "@example.com",
+		City:  "City" + strconv.Itoa(i%10),
 	}
-	return us
+}
+
+func genUsers(n int) []User {
+	return slicepat.GenerateSlice(n, newUser)
 }
 
 func genPtrUsers(n int) []*User {
-	us := make([]*User, n)
-	for i := 0; i < n; i++ {
-		u := User{
-			ID:    uint(i + 1),
-			Name:  "User_" + strconv.Itoa(i),
-			Age:   uint(18 + (i % 50)),
-			Email: "user" + strconv.Itoa(i) + "@example.com",
-			City:  "City" + strconv.Itoa(i%10),
-		}
-		us[i] = &u
-	}
-	return us
+	return slicepat.GenerateSlice(n, func(i int) *User {
+		u := newUser(i)
+		return &u
+	})
 }
 
 var (
@@ -156,6 +150,16 @@ func BenchmarkDTOTransform_PtrSlice(b *testing.B) {
 		SinkDTOs = dtos
 	}
 }
+func BenchmarkDTOTransform_Map(b *testing.B) {
+	src := genUsers(50000)
+	toDTO := func(u User) DTO {
+		return DTO{Identifier: strings.ToLower(u.Email), AgeGroup: groupAge(u.Age)}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkDTOs = slicepat.Map(src, toDTO)
+	}
+}
 
 // 実ワークロード: フィルタ
 func BenchmarkFilter_ValueSlice(b *testing.B) {
@@ -224,6 +228,14 @@ func BenchmarkGroupByCity_PtrSlice(b *testing.B) {
 		SinkInt = len(group)
 	}
 }
+func BenchmarkGroupByCity_Generic(b *testing.B) {
+	src := genUsers(50000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		group := slicepat.GroupBy(src, func(u User) string { return u.City })
+		SinkInt = len(group)
+	}
+}
 
 func groupAge(age uint) string {
 	switch {
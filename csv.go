@@ -0,0 +1,30 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ParseUsers は CSV から []User を組み立てる。各レコードの変換は parse に任せる。
+// 最初に失敗したレコードの行番号をエラーに含める（ヘッダ行も1レコードとして parse に渡る）。
+func ParseUsers(r io.Reader, parse func(record []string) (User, error)) ([]User, error) {
+	cr := csv.NewReader(r)
+	users := []User{}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return users, nil
+		}
+		if err != nil {
+			return nil, err // csv.ParseError は行番号を含む
+		}
+		u, err := parse(record)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		users = append(users, u)
+	}
+}
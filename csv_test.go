@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func userRecord(u User) []string {
+	return []string{strconv.Itoa(int(u.ID)), u.Name, strconv.Itoa(int(u.Age)), u.Email, u.City}
+}
+
+func parseUserRecord(rec []string) (User, error) {
+	if len(rec) != 5 {
+		return User{}, errors.New("want 5 fields")
+	}
+	id, err := strconv.ParseUint(rec[0], 10, 64)
+	if err != nil {
+		return User{}, err
+	}
+	age, err := strconv.ParseUint(rec[2], 10, 64)
+	if err != nil {
+		return User{}, err
+	}
+	return User{ID: uint(id), Name: rec[1], Age: uint(age), Email: rec[3], City: rec[4]}, nil
+}
+
+func TestParseUsers_RoundTrip(t *testing.T) {
+	src := genUsers(20)
+	src[3].Name = "Comma, \"Quoted\""
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, u := range src {
+		if err := w.Write(userRecord(u)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Flush()
+
+	got, err := ParseUsers(&buf, parseUserRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, src) {
+		t.Error("round trip through CSV changed the users")
+	}
+}
+
+func TestParseUsers_ErrorLine(t *testing.T) {
+	in := "1,Alice,20,a@example.com,Sendai\n" +
+		"2,Bob,thirty,b@example.com,Kanazawa\n" +
+		"3,Carol,x,c@example.com,Tokyo\n"
+	_, err := ParseUsers(strings.NewReader(in), parseUserRecord)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("err = %v, want error for line 2", err)
+	}
+}
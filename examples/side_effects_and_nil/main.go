@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+
+	"example.com/go-slice-patterns-workload/slicepat"
 )
 
 type User struct {
@@ -35,10 +37,10 @@ func sideEffectsDemo() {
 		{ID: 1, Name: "Alice", Email: "a@example.com", City: "Sendai"},
 		{ID: 2, Name: "Bob", Email: "b@example.com", City: "Kanazawa"},
 	}
-	ptrs := toPtrSlice(src)
+	ptrs := slicepat.ToPtrSlice(src)
 
 	// 「フィルタ」などで別のスライスを作るが、要素は同じポインタ参照
-	onlySendai := filterPtr(ptrs, func(u *User) bool { return u != nil && u.City == "Sendai" })
+	onlySendai := slicepat.Filter(ptrs, func(u *User) bool { return u != nil && u.City == "Sendai" })
 
 	// 片方を更新すると、もう片方にも影響する（共有参照ゆえ）
 	fmt.Printf("before: ptrs[0].Name=%q, onlySendai[0].Name=%q\n", ptrs[0].Name, onlySendai[0].Name)
@@ -69,19 +71,8 @@ func nilPitfallsDemo() {
 	// sort.Slice(ptrs, func(i, j int) bool { return ptrs[i].Name < ptrs[j].Name })
 
 	// nil対応したLess関数（nilは後ろへ）
-	sort.Slice(ptrs, func(i, j int) bool {
-		ui, uj := ptrs[i], ptrs[j]
-		switch {
-		case ui == nil && uj == nil:
-			return false
-		case ui == nil:
-			return false
-		case uj == nil:
-			return true
-		default:
-			return ui.Name < uj.Name
-		}
-	})
+	byName := slicepat.NilSafeLess(func(a, b *User) bool { return a.Name < b.Name })
+	sort.Slice(ptrs, func(i, j int) bool { return byName(ptrs[i], ptrs[j]) })
 	fmt.Println("sort ok（nilは末尾）:", ptrNames(ptrs))
 
 	// 2-3) JSONにそのまま流すと null が混ざる
@@ -89,7 +80,7 @@ func nilPitfallsDemo() {
 	fmt.Println("JSON(そのまま):", string(out)) // ...,"users":[{...},null,{...}]
 
 	// nilを除去してからJSONへ
-	cleaned := compactNonNil(ptrs)
+	cleaned := slicepat.CompactNonNil(ptrs)
 	out2, _ := json.Marshal(map[string]any{"users": cleaned})
 	fmt.Println("JSON(nil除去):", string(out2))
 }
@@ -106,13 +97,13 @@ func safePatternsDemo() {
 	}
 
 	// 共有参照にしない版（User値をコピーして新しいポインタを作る）
-	copied := filterPtrDeepCopy(src, func(u *User) bool { return u != nil && u.City == "Sendai" })
+	copied := slicepat.FilterDeepCopy(src, func(u *User) bool { return u.City == "Sendai" })
 	// これを更新してもsrc側に影響しない
 	copied[0].Name = "Alice-DeepCopied"
 	fmt.Printf("deepcopy update -> src[0].Name=%q, copied[0].Name=%q  <-- 独立\n", src[0].Name, copied[0].Name)
 
 	// 3-2) JSON出力時はnil除去 + 値スライス化（`null`混入回避＆API契約を安定化）
-	jsonReady := toValueSlice(compactNonNil(src))
+	jsonReady := slicepat.ToValueSlice(slicepat.CompactNonNil(src))
 	j, _ := json.MarshalIndent(map[string]any{"users": jsonReady}, "", "  ")
 	fmt.Println("JSON(値スライス化):\n" + string(j))
 
@@ -124,7 +115,7 @@ func safePatternsDemo() {
 	// 3-4) バッファの再利用や外部公開では必ずディープコピー
 	// APIレスポンスのキャッシュを返すとき等に重要
 	cache := []*User{{ID: 100, Name: "X"}, {ID: 101, Name: "Y"}}
-	safeExternal := deepCopyPtrSlice(cache) // 外部へ渡す前にディープコピーして独立させる
+	safeExternal := slicepat.DeepCopyPtrSlice(cache) // 外部へ渡す前にディープコピーして独立させる
 	safeExternal[0].Name = "X-Changed-Outside"
 	fmt.Printf("cache[0].Name=%q  <-- 外部更新の副作用を遮断\n", cache[0].Name)
 }
@@ -133,71 +124,6 @@ func safePatternsDemo() {
 // ユーティリティ
 // ----------------------------------------
 
-func toPtrSlice(vs []User) []*User {
-	out := make([]*User, len(vs))
-	for i := range vs {
-		u := vs[i]          // 新しい変数でアドレスが変わらないように
-		out[i] = &u         // &vs[i] だとループ変数の罠にならないが、慣習的にこの形が安全
-	}
-	return out
-}
-
-func toValueSlice(ps []*User) []User {
-	out := make([]User, 0, len(ps))
-	for _, p := range ps {
-		if p == nil {
-			continue
-		}
-		out = append(out, *p)
-	}
-	return out
-}
-
-func deepCopyPtrSlice(ps []*User) []*User {
-	out := make([]*User, 0, len(ps))
-	for _, p := range ps {
-		if p == nil {
-			out = append(out, nil)
-			continue
-		}
-		cp := *p
-		out = append(out, &cp)
-	}
-	return out
-}
-
-func filterPtr(ps []*User, pred func(*User) bool) []*User {
-	out := make([]*User, 0, len(ps))
-	for _, p := range ps {
-		if pred(p) {
-			out = append(out, p) // ← そのまま参照を渡す（副作用が伝播）
-		}
-	}
-	return out
-}
-
-func filterPtrDeepCopy(ps []*User, pred func(*User) bool) []*User {
-	out := make([]*User, 0, len(ps))
-	for _, p := range ps {
-		if p == nil || !pred(p) {
-			continue
-		}
-		cp := *p // 値コピーして新規ポインタを作る
-		out = append(out, &cp)
-	}
-	return out
-}
-
-func compactNonNil(ps []*User) []*User {
-	out := make([]*User, 0, len(ps))
-	for _, p := range ps {
-		if p != nil {
-			out = append(out, p)
-		}
-	}
-	return out
-}
-
 func ptrNames(ps []*User) string {
 	var b bytes.Buffer
 	b.WriteString("[")
@@ -1,3 +1,3 @@
 module example.com/go-slice-patterns-workload
 
-go 1.22
+go 1.23
@@ -0,0 +1,77 @@
+package slicepat
+
+// ProcessFrom は src[start:] の先頭 batchSize 件を1バッチとして fn に渡し、次に再開すべきインデックスを返す。
+// 成功すればバッチの終わり（次のバッチの先頭）を、fn がエラーを返せば start とそのエラーを返す。
+// 1回の呼び出しで処理するのは1バッチだけなので、呼び出しのたびに戻り値を保存しておけば、
+// 再起動後に ProcessFrom(src, next, ...) で途中から再開できる。
+//
+//	next := saved
+//	for next < len(src) {
+//		var err error
+//		if next, err = slicepat.ProcessFrom(src, next, 100, importBatch); err != nil {
+//			return err
+//		}
+//		save(next)
+//	}
+//
+// start が len(src) 以上なら fn を呼ばずに start と nil を返す。start が負なら 0 とみなす。
+// fn に渡すバッチは src の部分スライスなので、fn 内で保持・変更しないこと。
+// batchSize が 0 以下なら panic する。
+func ProcessFrom[T any](src []T, start int, batchSize int, fn func(batch []T) error) (nextIndex int, err error) {
+	if batchSize <= 0 {
+		panic("slicepat: ProcessFrom: batchSize must be positive")
+	}
+	if start < 0 {
+		start = 0
+	}
+	if start >= len(src) {
+		return start, nil
+	}
+	end := start + min(batchSize, len(src)-start)
+	if err := fn(src[start:end]); err != nil {
+		return start, err
+	}
+	return end, nil
+}
+
+// Chunk は s を先頭から size 件ずつに区切ったスライスの列を返す。最後の塊は size より短いことがある。
+// 各塊はコピーではなく s の部分スライスなので、塊の要素を書き換えると s も変わる
+// （容量は塊の長さに絞ってあるので、塊への append が隣の塊を上書きすることはない）。
+// 独立した塊が必要なら ChunkCopy を使う。s が空なら空の（nil でない）スライスを返す。
+// size が 0 以下なら panic する。
+func Chunk[T any](s []T, size int) [][]T {
+	if size <= 0 {
+		panic("slicepat: Chunk: size must be positive")
+	}
+	out := make([][]T, 0, chunkCount(len(s), size))
+	for i := 0; i < len(s); i += size {
+		end := min(i+size, len(s))
+		out = append(out, s[i:end:end])
+	}
+	return out
+}
+
+// ChunkCopy は Chunk と同じく s を size 件ずつに区切るが、各塊を個別に確保したコピーにする。
+// 塊を保持したり書き換えたりしても、隣の塊や s には影響しない。size が 0 以下なら panic する。
+func ChunkCopy[T any](s []T, size int) [][]T {
+	if size <= 0 {
+		panic("slicepat: ChunkCopy: size must be positive")
+	}
+	out := make([][]T, 0, chunkCount(len(s), size))
+	for i := 0; i < len(s); i += size {
+		c := make([]T, min(size, len(s)-i))
+		copy(c, s[i:])
+		out = append(out, c)
+	}
+	return out
+}
+
+// chunkCount は長さ n を size 件ずつに区切ったときの塊の数を返す。
+// (n+size-1)/size は size が大きいと溢れるので、割り算の余りで切り上げる。
+func chunkCount(n, size int) int {
+	c := n / size
+	if n%size != 0 {
+		c++
+	}
+	return c
+}
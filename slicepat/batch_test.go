@@ -0,0 +1,165 @@
+package slicepat
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestProcessFrom_ResumeAfterError(t *testing.T) {
+	src := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	errImport := errors.New("import failed")
+
+	var imported []int
+	failOnce := true
+	fn := func(batch []int) error {
+		for _, v := range batch {
+			if v == 5 && failOnce {
+				failOnce = false
+				return errImport
+			}
+		}
+		imported = append(imported, batch...)
+		return nil
+	}
+
+	// 1回に1バッチずつ処理し、成功するたびにチェックポイントを保存する
+	var checkpoints []int
+	next := 0
+	var err error
+	for next < len(src) {
+		if next, err = ProcessFrom(src, next, 3, fn); err != nil {
+			break
+		}
+		checkpoints = append(checkpoints, next)
+	}
+	if !errors.Is(err, errImport) {
+		t.Fatalf("err = %v, want %v", err, errImport)
+	}
+	if next != 3 {
+		t.Fatalf("next = %d, want 3 (start of the failed batch)", next)
+	}
+	if !reflect.DeepEqual(checkpoints, []int{3}) {
+		t.Errorf("checkpoints = %v, want [3]", checkpoints)
+	}
+
+	// チェックポイントから再開する
+	for next < len(src) {
+		if next, err = ProcessFrom(src, next, 3, fn); err != nil {
+			t.Fatal(err)
+		}
+		checkpoints = append(checkpoints, next)
+	}
+	if want := []int{3, 6, 9, 10}; !reflect.DeepEqual(checkpoints, want) {
+		t.Errorf("checkpoints = %v, want %v", checkpoints, want)
+	}
+	if !reflect.DeepEqual(imported, src) {
+		t.Errorf("imported = %v, want %v", imported, src)
+	}
+}
+
+func TestProcessFrom_StartPastEnd(t *testing.T) {
+	called := false
+	next, err := ProcessFrom([]int{1, 2}, 5, 2, func([]int) error { called = true; return nil })
+	if err != nil || next != 5 || called {
+		t.Errorf("next=%d err=%v called=%v", next, err, called)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		n, size int
+		want    []int // 各塊の長さ
+	}{
+		{9, 3, []int{3, 3, 3}},
+		{10, 3, []int{3, 3, 3, 1}},
+		{2, 5, []int{2}},
+		{0, 3, []int{}},
+	}
+	for _, tt := range tests {
+		src := genUsers(tt.n)
+		chunks := Chunk(src, tt.size)
+		if chunks == nil {
+			t.Fatalf("n=%d: got nil outer slice", tt.n)
+		}
+		lens := make([]int, len(chunks))
+		var flat []user
+		for i, c := range chunks {
+			lens[i] = len(c)
+			flat = append(flat, c...)
+		}
+		if !reflect.DeepEqual(lens, tt.want) {
+			t.Errorf("n=%d size=%d: chunk lengths %v, want %v", tt.n, tt.size, lens, tt.want)
+		}
+		if tt.n > 0 && !reflect.DeepEqual(flat, src) {
+			t.Errorf("n=%d size=%d: chunks do not reassemble src", tt.n, tt.size)
+		}
+	}
+}
+
+func TestChunk_HugeSize(t *testing.T) {
+	src := []int{1, 2, 3}
+	got := Chunk(src, math.MaxInt)
+	if want := [][]int{{1, 2, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestChunk_Aliasing(t *testing.T) {
+	src := []int{1, 2, 3, 4, 5}
+	chunks := Chunk(src, 2)
+	chunks[0][1] = 20
+	if src[1] != 20 {
+		t.Error("chunks should share src's backing array")
+	}
+	_ = append(chunks[0], 99)
+	if src[2] != 3 {
+		t.Error("append to a chunk overwrote the next chunk")
+	}
+}
+
+func TestChunk_InvalidSize(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for size 0")
+		}
+	}()
+	Chunk([]int{1}, 0)
+}
+
+func TestChunkCopy(t *testing.T) {
+	src := genUsers(7) // 3,3,1 に分かれる（最後の境界は半端）
+	orig := append([]user(nil), src...)
+	chunks := ChunkCopy(src, 3)
+	if len(chunks) != 3 || len(chunks[2]) != 1 {
+		t.Fatalf("chunk lengths wrong: %d chunks", len(chunks))
+	}
+
+	chunks[1][0].Name = "changed"
+	chunks[1] = append(chunks[1], user{Name: "appended"})
+	chunks[2][0].Name = "changed-last"
+	if !reflect.DeepEqual(src, orig) {
+		t.Error("mutating chunks changed the source")
+	}
+	if chunks[0][2].Name != "User_2" || len(chunks[0]) != 3 {
+		t.Error("mutating chunk 1 affected chunk 0")
+	}
+	if chunks[2][0].Name != "changed-last" {
+		t.Error("append to chunk 1 overwrote chunk 2")
+	}
+	if &chunks[0][0] == &src[0] {
+		t.Error("chunk shares the source backing array")
+	}
+}
+
+func TestChunkCopy_HugeSize(t *testing.T) {
+	src := []int{1, 2, 3}
+	got := ChunkCopy(src, math.MaxInt)
+	if want := [][]int{{1, 2, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if cap(got[0]) != len(src) {
+		t.Errorf("cap = %d, want %d", cap(got[0]), len(src))
+	}
+}
@@ -0,0 +1,274 @@
+package slicepat
+
+import (
+	"testing"
+	"time"
+)
+
+type dto struct {
+	Identifier string
+	AgeGroup   string
+}
+
+var (
+	SinkInt    int
+	SinkString string
+	SinkUsers  []user
+	SinkUPtrs  []*user
+	SinkDTOs   []dto
+)
+
+func toDTO(u user) dto {
+	return dto{Identifier: u.Email, AgeGroup: groupAge(u.Age)}
+}
+
+// パイプライン: 融合（1パス） vs 即時評価の連結
+func BenchmarkPipeline_Fused(b *testing.B) {
+	src := genUsers(50000)
+	p := NewPipeline[user]().
+		Map(func(u user) user { u.Age++; return u }).
+		Filter(func(u user) bool { return u.City == "City5" }).
+		Take(4000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkUsers = p.Run(src)
+	}
+}
+func BenchmarkPipeline_Eager(b *testing.B) {
+	src := genUsers(50000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkUsers = eagerMapFilterTake(src,
+			func(u user) user { u.Age++; return u },
+			func(u user) bool { return u.City == "City5" },
+			4000)
+	}
+}
+
+// Map: 毎回確保 vs バッファ再利用
+func BenchmarkMap_Alloc(b *testing.B) {
+	src := genUsers(50000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst := make([]dto, len(src))
+		for j, u := range src {
+			dst[j] = toDTO(u)
+		}
+		SinkDTOs = dst
+	}
+}
+func BenchmarkMap_Reuse(b *testing.B) {
+	src := genUsers(50000)
+	var dst []dto
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst = MapReuse(dst, src, toDTO)
+	}
+	SinkDTOs = dst
+}
+
+// nil除去 + 値スライス化: 1パス vs 2段階
+func genSparsePtrUsers(n int) []*user {
+	src := genPtrUsers(n)
+	for i := 0; i < n; i += 10 {
+		src[i] = nil
+	}
+	return src
+}
+func BenchmarkCompactDeref_OnePass(b *testing.B) {
+	src := genSparsePtrUsers(50000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkUsers = CompactDeref(src)
+	}
+}
+func BenchmarkCompactDeref_TwoStep(b *testing.B) {
+	src := genSparsePtrUsers(50000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		compacted := make([]*user, 0, len(src))
+		for _, p := range src {
+			if p != nil {
+				compacted = append(compacted, p)
+			}
+		}
+		values := make([]user, 0, len(compacted))
+		for _, p := range compacted {
+			values = append(values, *p)
+		}
+		SinkUsers = values
+	}
+}
+
+// フィルタ: 浅い（ポインタ共有） vs ディープコピー
+func BenchmarkFilter_Shallow(b *testing.B) {
+	src := genPtrUsers(50000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkUPtrs = Filter(src, func(u *user) bool { return u.City == "City5" })
+	}
+}
+func BenchmarkFilter_DeepCopy(b *testing.B) {
+	src := genPtrUsers(50000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkUPtrs = FilterDeepCopy(src, func(u *user) bool { return u.City == "City5" })
+	}
+}
+
+// ディープコピー: 毎回確保 vs 既存領域の再利用
+func BenchmarkDeepCopy_Fresh(b *testing.B) {
+	src := genPtrUsers(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkUPtrs = DeepCopyPtrSlice(src)
+	}
+}
+func BenchmarkDeepCopy_Into(b *testing.B) {
+	src := genPtrUsers(100000)
+	dst := make([]*user, len(src))
+	assign := func(d, s *user) { *d = *s }
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DeepCopyInto(dst, src, assign)
+	}
+	SinkUPtrs = dst
+}
+
+// 年齢の合計: 直列 Reduce vs ParallelReduce
+func BenchmarkReduce_Serial(b *testing.B) {
+	src := genUsers(100000)
+	sumAge := func(acc int, u user) int { return acc + int(u.Age) }
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkInt = Reduce(src, 0, sumAge)
+	}
+}
+func BenchmarkReduce_Parallel(b *testing.B) {
+	src := genUsers(100000)
+	sumAge := func(acc int, u user) int { return acc + int(u.Age) }
+	add := func(a, b int) int { return a + b }
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkInt = ParallelReduce(src, 0, 0, sumAge, add)
+	}
+}
+
+// f が待ちを含む変換: 直列 Map vs ParallelMap（待ちは CPU を使わないので workers は CPU 数と無関係に 8）
+func slowDTO(u user) dto {
+	time.Sleep(10 * time.Microsecond)
+	return toDTO(u)
+}
+func BenchmarkMap_SlowSerial(b *testing.B) {
+	src := genUsers(200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkDTOs = Map(src, slowDTO)
+	}
+}
+func BenchmarkMap_SlowParallel(b *testing.B) {
+	src := genUsers(200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkDTOs = ParallelMap(src, 8, slowDTO)
+	}
+}
+
+// 重い述語での絞り込み: 直列 Filter vs ParallelFilter（差は CPU 数に比例する）
+func slowPred(u user) bool {
+	h := uint32(u.ID)
+	for i := 0; i < 2000; i++ {
+		h = h*16777619 ^ uint32(i)
+	}
+	return h%2 == 0
+}
+func BenchmarkFilter_SlowSerial(b *testing.B) {
+	src := genUsers(50000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkUsers = Filter(src, slowPred)
+	}
+}
+func BenchmarkFilter_SlowParallel(b *testing.B) {
+	src := genUsers(50000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkUsers = ParallelFilter(src, 0, slowPred)
+	}
+}
+
+// Email での重複除去（重複率10%）
+func BenchmarkDedupeBy(b *testing.B) {
+	src := genUsers(50000)
+	for i := 0; i < len(src); i += 10 {
+		src[i].Email = src[(i+5)%len(src)].Email
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkUsers = DedupeBy(src, func(u user) string { return u.Email })
+	}
+}
+
+// ソート済みスライスの重複除去: その場 vs 新規確保
+func genSortedIDs(n int) []int {
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i / 3 // 各値が3回ずつ並ぶ
+	}
+	return ids
+}
+func BenchmarkDedup_AdjacentInPlace(b *testing.B) {
+	src := genSortedIDs(50000)
+	work := make([]int, len(src))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(work, src)
+		SinkInt = len(DedupAdjacentInPlace(work))
+	}
+}
+func BenchmarkDedup_StableUnique(b *testing.B) {
+	src := genSortedIDs(50000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out, _ := StableUnique(src)
+		SinkInt = len(out)
+	}
+}
+
+// 名前の連結: strings.Builder vs + 連結
+func BenchmarkJoinString_Builder(b *testing.B) {
+	src := genUsers(2000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SinkString = JoinString(src, ",", func(u user) string { return u.Name })
+	}
+}
+func BenchmarkJoinString_Concat(b *testing.B) {
+	src := genUsers(2000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := ""
+		for j, u := range src {
+			if j > 0 {
+				s += ","
+			}
+			s += u.Name
+		}
+		SinkString = s
+	}
+}
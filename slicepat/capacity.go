@@ -0,0 +1,39 @@
+package slicepat
+
+// AppendNoAlias は append(dst, vals...) の結果と、その際に配列の再確保が起きたかを返す。
+// 再確保が起きなければ結果は dst と同じ配列を共有しているので、dst[:cap(dst)] を
+// 見ている他のスライスからも書き込みが見える。再確保が起きた場合は逆に、
+// 共有していたつもりの他のスライスから見て結果は古くなる。どちらなのかを呼び出し側で判別するための診断用。
+func AppendNoAlias[T any](dst []T, vals ...T) ([]T, bool) {
+	reallocated := len(dst)+len(vals) > cap(dst)
+	return append(dst, vals...), reallocated
+}
+
+// Truncate は src の先頭 max 件を返す。len(src) が max 以下なら src をそのまま返す。
+// 結果はコピーではなく src と配列を共有するビューだが、容量も max に絞るので
+// 結果に append しても src の後続要素は上書きされない。max が負なら 0 とみなす。
+// API レスポンスの件数上限を掛ける用途を想定している。
+func Truncate[T any](src []T, max int) []T {
+	if len(src) <= max {
+		return src
+	}
+	if max < 0 {
+		max = 0
+	}
+	return src[:max:max]
+}
+
+// ShrinkToFit は cap == len となる src のコピーを返す。余分に確保した配列を手放せるので、
+// 大きなバッファから絞り込んだ結果を長期間キャッシュする前に使う。
+// append でのコピーは容量がサイズクラスに切り上がるため、make と copy で確保する。
+func ShrinkToFit[T any](src []T) []T {
+	out := make([]T, len(src))
+	copy(out, src)
+	return out
+}
+
+// CapacityReport は src の長さ・容量と、使われていない容量（cap - len）を返す。
+// wasted が大きいスライスは ShrinkToFit で詰める候補になる。
+func CapacityReport[T any](src []T) (length, capacity, wasted int) {
+	return len(src), cap(src), cap(src) - len(src)
+}
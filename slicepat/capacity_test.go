@@ -0,0 +1,95 @@
+package slicepat
+
+import "testing"
+
+func TestAppendNoAlias(t *testing.T) {
+	base := make([]int, 2, 4)
+
+	// 容量内: 同じ配列を共有する
+	got, reallocated := AppendNoAlias(base, 1, 2)
+	if reallocated {
+		t.Error("append within capacity reported reallocation")
+	}
+	got[0] = 99
+	if base[0] != 99 {
+		t.Error("expected result to share base's backing array")
+	}
+
+	// 容量超過: 新しい配列になる
+	got, reallocated = AppendNoAlias(base, 1, 2, 3)
+	if !reallocated {
+		t.Error("append beyond capacity did not report reallocation")
+	}
+	got[0] = -1
+	if base[0] == -1 {
+		t.Error("result should no longer share base's backing array")
+	}
+
+	if _, reallocated := AppendNoAlias([]int(nil)); reallocated {
+		t.Error("appending nothing to nil reported reallocation")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	src := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		max  int
+		want int
+	}{
+		{10, 5}, // 短い
+		{5, 5},  // ちょうど
+		{3, 3},  // 超過
+		{0, 0},
+		{-1, 0},
+	}
+	for _, tt := range tests {
+		if got := Truncate(src, tt.max); len(got) != tt.want {
+			t.Errorf("Truncate(max=%d) len = %d, want %d", tt.max, len(got), tt.want)
+		}
+	}
+
+	// 切り詰めた結果への append は src を壊さない
+	got := append(Truncate(src, 3), 99)
+	if src[3] != 4 {
+		t.Errorf("src[3] = %d, append through truncated view clobbered src", src[3])
+	}
+	if got[3] != 99 {
+		t.Errorf("got[3] = %d, want 99", got[3])
+	}
+}
+
+func TestShrinkToFit(t *testing.T) {
+	src := make([]user, 3, 1000)
+	src[1].Name = "Bob"
+
+	got := ShrinkToFit(src)
+	if len(got) != 3 || cap(got) != 3 {
+		t.Errorf("len, cap = %d, %d, want 3, 3", len(got), cap(got))
+	}
+	if got[1].Name != "Bob" {
+		t.Errorf("got[1].Name = %q, want Bob", got[1].Name)
+	}
+	got[1].Name = "changed"
+	if src[1].Name != "Bob" {
+		t.Error("result should not share src's backing array")
+	}
+
+	// append によるコピーだと容量が切り上がりうる長さでも一致する
+	odd := make([]byte, 5, 64)
+	if got := ShrinkToFit(odd); cap(got) != len(got) {
+		t.Errorf("cap = %d, want %d", cap(got), len(got))
+	}
+}
+
+func TestCapacityReport(t *testing.T) {
+	src := make([]user, 3, 100)
+	if l, c, w := CapacityReport(src); l != 3 || c != 100 || w != 97 {
+		t.Errorf("got %d, %d, %d, want 3, 100, 97", l, c, w)
+	}
+	if l, c, w := CapacityReport(ShrinkToFit(src)); l != 3 || c != 3 || w != 0 {
+		t.Errorf("after ShrinkToFit: got %d, %d, %d, want 3, 3, 0", l, c, w)
+	}
+	if l, c, w := CapacityReport([]user(nil)); l != 0 || c != 0 || w != 0 {
+		t.Errorf("nil: got %d, %d, %d, want 0, 0, 0", l, c, w)
+	}
+}
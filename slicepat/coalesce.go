@@ -0,0 +1,32 @@
+package slicepat
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CoalesceRecords は部分的にしか埋まっていない複数のレコードを1つにまとめた新しい値を返す。
+// fields に挙げた各フィールドについて、records を先頭から見て isSet(field, rec) が true になった
+// 最初のレコードの値を採用する。どのレコードでも未設定ならゼロ値のまま。nil のレコードは飛ばす。
+// 複数のデータソースから集めたユーザー情報の突き合わせに使う。
+// T は構造体でなければならず、存在しない（または非公開の）フィールド名を指定すると panic する。
+func CoalesceRecords[T any](records []*T, isSet func(field string, v *T) bool, fields []string) *T {
+	out := new(T)
+	dst := reflect.ValueOf(out).Elem()
+	if dst.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("slicepat: CoalesceRecords: %v is not a struct", dst.Type()))
+	}
+	for _, name := range fields {
+		f, ok := dst.Type().FieldByName(name)
+		if !ok || !f.IsExported() {
+			panic(fmt.Sprintf("slicepat: CoalesceRecords: unknown field %q in %v", name, dst.Type()))
+		}
+		for _, rec := range records {
+			if rec != nil && isSet(name, rec) {
+				dst.FieldByIndex(f.Index).Set(reflect.ValueOf(rec).Elem().FieldByIndex(f.Index))
+				break
+			}
+		}
+	}
+	return out
+}
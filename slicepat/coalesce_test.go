@@ -0,0 +1,40 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ゼロ値でなければ設定済みとみなす
+func userFieldSet(field string, u *user) bool {
+	return !reflect.ValueOf(u).Elem().FieldByName(field).IsZero()
+}
+
+func TestCoalesceRecords(t *testing.T) {
+	fromCRM := &user{ID: 1, Name: "Alice", Email: "a@example.com"}
+	fromSignup := &user{ID: 1, Name: "alice", Age: 20, City: "Sendai"}
+	fields := []string{"ID", "Name", "Age", "Email", "City"}
+
+	got := CoalesceRecords([]*user{nil, fromCRM, fromSignup}, userFieldSet, fields)
+	want := user{ID: 1, Name: "Alice", Age: 20, Email: "a@example.com", City: "Sendai"}
+	if *got != want {
+		t.Errorf("got %+v, want %+v", *got, want)
+	}
+	if got == fromCRM || got == fromSignup {
+		t.Error("result should be a new record")
+	}
+
+	// 挙げていないフィールドはゼロ値
+	if got := CoalesceRecords([]*user{fromSignup}, userFieldSet, []string{"City"}); *got != (user{City: "Sendai"}) {
+		t.Errorf("got %+v, want only City", *got)
+	}
+}
+
+func TestCoalesceRecords_UnknownField(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unknown field")
+		}
+	}()
+	CoalesceRecords([]*user{{}}, userFieldSet, []string{"Phone"})
+}
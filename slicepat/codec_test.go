@@ -0,0 +1,25 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	src := genUsers(100)
+	data, err := Encode(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Decode[user](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, src) {
+		t.Error("round trip through gob changed the users")
+	}
+
+	if _, err := Decode[user]([]byte("not gob")); err == nil {
+		t.Error("expected error for invalid input")
+	}
+}
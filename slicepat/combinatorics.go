@@ -0,0 +1,98 @@
+package slicepat
+
+import "errors"
+
+// Pair は2つの値の組。
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Product は a と b の直積（全組み合わせ）を返す。a の順に、各要素について b の順で並ぶ。
+// どちらかが空なら空スライスを返す。テストデータ（ユーザー × 都市など）の生成に使う。
+func Product[A, B any](a []A, b []B) []Pair[A, B] {
+	out := make([]Pair[A, B], 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			out = append(out, Pair[A, B]{First: x, Second: y})
+		}
+	}
+	return out
+}
+
+// Combinations は src から k 個を選ぶ組み合わせ（順序を区別しない）をすべて返す。
+// 各組み合わせ内の要素は src での順に並び、組み合わせ同士は辞書順に並ぶ。
+// k == 0 なら空の組み合わせ1つ、k < 0 または k > len(src) なら空スライスを返す。
+// 各組み合わせは独立したスライスなので、変更しても他に影響しない。
+func Combinations[T any](src []T, k int) [][]T {
+	out := [][]T{}
+	if k < 0 || k > len(src) {
+		return out
+	}
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+	for {
+		comb := make([]T, k)
+		for i, j := range idx {
+			comb[i] = src[j]
+		}
+		out = append(out, comb)
+
+		// 右端から、まだ進められるインデックスを探して進める
+		i := k - 1
+		for i >= 0 && idx[i] == len(src)-k+i {
+			i--
+		}
+		if i < 0 {
+			return out
+		}
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
+
+// MaxPermutationLen は Permutations が受け付ける入力長の上限。
+// 10! = 3,628,800 通りを超える生成はメモリを食い潰すので打ち切る。
+const MaxPermutationLen = 10
+
+// ErrTooManyPermutations は Permutations の入力が MaxPermutationLen を超えたときに返る。
+var ErrTooManyPermutations = errors.New("slicepat: too many elements to permute")
+
+// Permutations は src の要素の並べ方をすべて返す。結果の件数は len(src)! なので
+// 小さなスライス専用で、len(src) が MaxPermutationLen を超えると ErrTooManyPermutations を返す。
+// 結果は src のインデックス順で辞書順に並ぶ。空の入力には空の並べ方1つを返す。
+func Permutations[T any](src []T) ([][]T, error) {
+	if len(src) > MaxPermutationLen {
+		return nil, ErrTooManyPermutations
+	}
+	total := 1
+	for i := 2; i <= len(src); i++ {
+		total *= i
+	}
+	out := make([][]T, 0, total)
+	cur := make([]T, 0, len(src))
+	used := make([]bool, len(src))
+	var walk func()
+	walk = func() {
+		if len(cur) == len(src) {
+			out = append(out, append([]T(nil), cur...))
+			return
+		}
+		for i, v := range src {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			cur = append(cur, v)
+			walk()
+			cur = cur[:len(cur)-1]
+			used[i] = false
+		}
+	}
+	walk()
+	return out, nil
+}
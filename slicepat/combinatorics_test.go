@@ -0,0 +1,79 @@
+package slicepat
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestProduct(t *testing.T) {
+	users := []string{"Alice", "Bob", "Carol"}
+	cities := []string{"Sendai", "Kanazawa"}
+
+	got := Product(users, cities)
+	if len(got) != len(users)*len(cities) {
+		t.Fatalf("len = %d, want %d", len(got), len(users)*len(cities))
+	}
+	if want := (Pair[string, string]{"Alice", "Kanazawa"}); got[1] != want {
+		t.Errorf("got[1] = %v, want %v", got[1], want)
+	}
+	if want := (Pair[string, string]{"Carol", "Sendai"}); got[4] != want {
+		t.Errorf("got[4] = %v, want %v", got[4], want)
+	}
+
+	if got := Product(users, []int(nil)); got == nil || len(got) != 0 {
+		t.Errorf("empty input: got %v, want empty non-nil slice", got)
+	}
+	if got := Product([]int{}, cities); !reflect.DeepEqual(got, []Pair[int, string]{}) {
+		t.Errorf("empty input: got %v", got)
+	}
+}
+
+func binomial(n, k int) int {
+	r := 1
+	for i := 1; i <= k; i++ {
+		r = r * (n - k + i) / i
+	}
+	return r
+}
+
+func TestCombinations(t *testing.T) {
+	src := []string{"a", "b", "c", "d", "e"}
+	for k := 0; k <= len(src); k++ {
+		got := Combinations(src, k)
+		if len(got) != binomial(len(src), k) {
+			t.Errorf("k=%d: len = %d, want C(%d,%d) = %d", k, len(got), len(src), k, binomial(len(src), k))
+		}
+		for _, c := range got {
+			if len(c) != k {
+				t.Errorf("k=%d: combination %v has wrong size", k, c)
+			}
+		}
+	}
+
+	want := [][]string{{"a", "b"}, {"a", "c"}, {"b", "c"}}
+	if got := Combinations(src[:3], 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := Combinations(src, 0); len(got) != 1 || len(got[0]) != 0 {
+		t.Errorf("k=0: got %v, want one empty combination", got)
+	}
+	if got := Combinations(src, 6); got == nil || len(got) != 0 {
+		t.Errorf("k>len: got %v, want empty", got)
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	got, err := Permutations([]int{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := Permutations(make([]int, MaxPermutationLen+1)); !errors.Is(err, ErrTooManyPermutations) {
+		t.Errorf("err = %v, want %v", err, ErrTooManyPermutations)
+	}
+}
@@ -0,0 +1,207 @@
+package slicepat
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// workerCount は workers を正規化する。0 以下なら GOMAXPROCS を使い、n を超えないよう切り詰める。
+func workerCount(workers, n int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+	return workers
+}
+
+// IndexRanges は [0,length) を parts 個のほぼ同じ長さの区間 [start,end) に分けて返す。
+// 区間は連続して全体を覆い、長さの差は高々1。スライスをコピーせずに自前の並列ループを回すための部品で、
+// このパッケージの並列処理もこれで区間を割り当てている。
+// 空の区間は作らないので、parts が length より大きければ length 個の区間になる。
+// length か parts が 0 以下なら nil を返す。
+func IndexRanges(length, parts int) [][2]int {
+	if parts <= 0 || length <= 0 {
+		return nil
+	}
+	parts = min(parts, length)
+	out := make([][2]int, parts)
+	size, rem := length/parts, length%parts
+	start := 0
+	for p := range out {
+		end := start + size
+		if p < rem {
+			end++
+		}
+		out[p] = [2]int{start, end}
+		start = end
+	}
+	return out
+}
+
+// MapConcurrent は src の各要素に f を workers 個のゴルーチンで並行に適用し、
+// 結果を入力と同じ順番で返す。workers が 0 以下なら GOMAXPROCS を使う。
+//
+// f がエラーを返した場合は、元のインデックスが最も小さいエラーを IndexedError として返す。
+// エラーが起きた位置より後ろの要素は以降処理しない（それより前の要素は最後まで処理して、
+// より手前にエラーがないかを確かめる）。エラー時の結果スライスは nil。
+func MapConcurrent[T, U any](src []T, workers int, f func(T) (U, error)) ([]U, error) {
+	out := make([]U, len(src))
+	var (
+		next     atomic.Int64
+		failedAt atomic.Int64
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	failedAt.Store(int64(len(src)))
+	for w := workerCount(workers, len(src)); w > 0; w-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				// インデックスは昇順に払い出すので、失敗位置より後ろを引いたら以降も不要
+				i := next.Add(1) - 1
+				if i >= int64(len(src)) || i > failedAt.Load() {
+					return
+				}
+				v, err := f(src[i])
+				if err != nil {
+					mu.Lock()
+					if i < failedAt.Load() {
+						failedAt.Store(i)
+						firstErr = IndexedError{Index: int(i), Err: err}
+					}
+					mu.Unlock()
+					continue
+				}
+				out[i] = v
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return out, nil
+}
+
+// ParallelMap は s の各要素に f を workers 個のゴルーチンで並行に適用し、結果を入力と同じ順番で返す
+// （重い DTO 変換の並列化など）。workers が 0 以下なら GOMAXPROCS を使う。
+// 各要素に f を呼ぶのはちょうど1回で、結果[i] は常に s[i] に対応する。
+// 中身は失敗しない f で MapConcurrent を呼ぶのと同じ。f が軽い場合は直列の Map の方が速い。
+func ParallelMap[T, U any](s []T, workers int, f func(T) U) []U {
+	out, _ := MapConcurrent(s, workers, func(v T) (U, error) { return f(v), nil })
+	return out
+}
+
+// ParallelFilter は s を workers 個の連続した区間に分けて区間ごとに並行に pred で絞り込み、
+// 結果を区間順に連結して返す。pred が重い場合向けで、結果は Filter と同じく s の順を保つ。
+// workers が 0 以下なら GOMAXPROCS を使う。該当なしでも nil ではなく空スライスを返す。
+func ParallelFilter[T any](s []T, workers int, pred func(T) bool) []T {
+	ranges := IndexRanges(len(s), workerCount(workers, len(s)))
+	partials := make([][]T, len(ranges))
+	var wg sync.WaitGroup
+	for p, r := range ranges {
+		wg.Add(1)
+		go func(p int, part []T) {
+			defer wg.Done()
+			partials[p] = Filter(part, pred)
+		}(p, s[r[0]:r[1]])
+	}
+	wg.Wait()
+
+	n := 0
+	for _, part := range partials {
+		n += len(part)
+	}
+	out := make([]T, 0, n)
+	for _, part := range partials {
+		out = append(out, part...)
+	}
+	return out
+}
+
+// MapBatches は src を batchSize 件ずつのバッチに分けて fn で並行に処理し、
+// 各バッチの結果を元のバッチ順に連結して返す。一括 API をバッチ単位で並列に呼ぶ場面を想定している。
+// エラーの扱いは MapConcurrent と同じで、最も手前のバッチのエラーを返す（インデックスはバッチ番号）。
+// fn に渡すバッチは src の部分スライスなので、fn 内で保持・変更しないこと。
+// batchSize が 0 以下なら panic する。
+func MapBatches[T, U any](src []T, batchSize, workers int, fn func(batch []T) ([]U, error)) ([]U, error) {
+	if batchSize <= 0 {
+		panic("slicepat: MapBatches: batchSize must be positive")
+	}
+	results, err := MapConcurrent(Chunk(src, batchSize), workers, fn)
+	if err != nil {
+		return nil, err
+	}
+	n := 0
+	for _, r := range results {
+		n += len(r)
+	}
+	out := make([]U, 0, n)
+	for _, r := range results {
+		out = append(out, r...)
+	}
+	return out, nil
+}
+
+// GroupByConcurrent は src を workers 個の連続した区間に分け、区間ごとに並行して key でグループ化してから
+// 部分結果をマージする。key の計算が重い場合向け。workers が 0 以下なら GOMAXPROCS を使う。
+// マージは区間順に行うので、結果としてグループ内の並びは src の順になるが、
+// この関数はそれを保証しない（将来マージ方法を変える可能性がある）。順序が必要なら GroupBy を使うこと。
+func GroupByConcurrent[K comparable, T any](src []T, workers int, key func(T) K) map[K][]T {
+	ranges := IndexRanges(len(src), workerCount(workers, len(src)))
+	partials := make([]map[K][]T, len(ranges))
+	var wg sync.WaitGroup
+	for p, r := range ranges {
+		wg.Add(1)
+		go func(p int, part []T) {
+			defer wg.Done()
+			m := make(map[K][]T)
+			for _, v := range part {
+				k := key(v)
+				m[k] = append(m[k], v)
+			}
+			partials[p] = m
+		}(p, src[r[0]:r[1]])
+	}
+	wg.Wait()
+
+	out := make(map[K][]T)
+	for _, m := range partials {
+		for k, vs := range m {
+			out[k] = append(out[k], vs...)
+		}
+	}
+	return out
+}
+
+// ParallelReduce は src を workers 個の区間に分け、区間ごとに init から reduce で並行に畳み込み、
+// 部分結果を区間順に combine でまとめる。workers が 0 以下なら GOMAXPROCS を使う。
+// 区間の切り方に結果が左右されないよう、combine は結合的で、init は combine の単位元
+// （和なら 0、積なら 1）でなければならない。src が空なら init を返す。
+func ParallelReduce[T, A any](src []T, workers int, init A, reduce func(A, T) A, combine func(A, A) A) A {
+	ranges := IndexRanges(len(src), workerCount(workers, len(src)))
+	if len(ranges) == 0 {
+		return init
+	}
+	partials := make([]A, len(ranges))
+	var wg sync.WaitGroup
+	for p, r := range ranges {
+		wg.Add(1)
+		go func(p int, part []T) {
+			defer wg.Done()
+			partials[p] = Reduce(part, init, reduce)
+		}(p, src[r[0]:r[1]])
+	}
+	wg.Wait()
+
+	acc := partials[0]
+	for _, v := range partials[1:] {
+		acc = combine(acc, v)
+	}
+	return acc
+}
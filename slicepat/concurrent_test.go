@@ -0,0 +1,264 @@
+package slicepat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMapConcurrent(t *testing.T) {
+	src := genUsers(1000)
+	got, err := MapConcurrent(src, 4, func(u user) (string, error) {
+		return strings.ToUpper(u.Name), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, u := range src {
+		if got[i] != strings.ToUpper(u.Name) {
+			t.Fatalf("got[%d] = %q, want %q", i, got[i], strings.ToUpper(u.Name))
+		}
+	}
+
+	// workers <= 0 でも動く
+	got, err = MapConcurrent(src[:3], 0, func(u user) (string, error) { return u.Name, nil })
+	if err != nil || !reflect.DeepEqual(got, []string{"User_0", "User_1", "User_2"}) {
+		t.Errorf("workers=0: got %v, %v", got, err)
+	}
+}
+
+func TestMapConcurrent_ReturnsErrorOfLowestIndex(t *testing.T) {
+	src := make([]int, 200)
+	for i := range src {
+		src[i] = i
+	}
+	errAt := func(i int) error { return fmt.Errorf("bad element %d", i) }
+	var calls atomic.Int64
+
+	_, err := MapConcurrent(src, 8, func(v int) (int, error) {
+		calls.Add(1)
+		if v == 100 || v == 150 {
+			return 0, errAt(v)
+		}
+		return v, nil
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "index 100") || !strings.Contains(err.Error(), "bad element 100") {
+		t.Errorf("err = %v, want error for index 100", err)
+	}
+	var ie IndexedError
+	if !errors.As(err, &ie) || ie.Index != 100 {
+		t.Errorf("err = %#v, want IndexedError at index 100", err)
+	}
+	if errors.Unwrap(err) == nil {
+		t.Error("expected wrapped error")
+	}
+	if c := calls.Load(); c < 101 {
+		t.Errorf("f called %d times, want every element up to index 100", c)
+	}
+}
+
+// go test -race で実行すると、結果スライスへの書き込みが競合していないことも確かめられる。
+func TestParallelMap(t *testing.T) {
+	src := genUsers(1000)
+	calls := make([]atomic.Int32, len(src))
+	got := ParallelMap(src, 8, func(u user) string {
+		calls[u.ID-1].Add(1)
+		return strings.ToUpper(u.Name)
+	})
+	if len(got) != len(src) {
+		t.Fatalf("len = %d, want %d", len(got), len(src))
+	}
+	for i, u := range src {
+		if got[i] != strings.ToUpper(u.Name) {
+			t.Fatalf("got[%d] = %q, want %q", i, got[i], strings.ToUpper(u.Name))
+		}
+		if n := calls[i].Load(); n != 1 {
+			t.Errorf("f called %d times for index %d, want 1", n, i)
+		}
+	}
+
+	if got := ParallelMap(src[:3], 0, func(u user) uint { return u.ID }); !reflect.DeepEqual(got, []uint{1, 2, 3}) {
+		t.Errorf("workers=0: got %v", got)
+	}
+	if got := ParallelMap([]user{}, 4, func(u user) uint { return u.ID }); got == nil || len(got) != 0 {
+		t.Errorf("empty: got %v, want empty", got)
+	}
+}
+
+// go test -race で実行すると、区間ごとの結果の書き込みが競合していないことも確かめられる。
+func TestParallelFilter_MatchesFilter(t *testing.T) {
+	src := genUsers(1000)
+	pred := func(u user) bool { return u.ID%3 == 0 || u.City == "City1" }
+	want := Filter(src, pred)
+	for _, workers := range []int{0, 1, 3, 8, 5000} {
+		if got := ParallelFilter(src, workers, pred); !reflect.DeepEqual(got, want) {
+			t.Errorf("workers=%d: result differs from Filter", workers)
+		}
+	}
+
+	if got := ParallelFilter(src, 4, func(user) bool { return false }); got == nil || len(got) != 0 {
+		t.Errorf("no match: got %v, want empty", got)
+	}
+	if got := ParallelFilter([]user(nil), 4, pred); got == nil || len(got) != 0 {
+		t.Errorf("nil src: got %v, want empty", got)
+	}
+}
+
+func TestMapBatches(t *testing.T) {
+	src := genUsers(103)
+	var batches atomic.Int64
+	got, err := MapBatches(src, 10, 4, func(batch []user) ([]uint, error) {
+		batches.Add(1)
+		ids := make([]uint, len(batch))
+		for i, u := range batch {
+			ids[i] = u.ID
+		}
+		return ids, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batches.Load() != 11 {
+		t.Errorf("fn called %d times, want 11", batches.Load())
+	}
+	if len(got) != len(src) {
+		t.Fatalf("len = %d, want %d", len(got), len(src))
+	}
+	for i, u := range src {
+		if got[i] != u.ID {
+			t.Fatalf("got[%d] = %d, want %d", i, got[i], u.ID)
+		}
+	}
+}
+
+func TestMapBatches_HugeBatchSize(t *testing.T) {
+	src := genUsers(5)
+	var sizes []int
+	got, err := MapBatches(src, math.MaxInt, 4, func(batch []user) ([]user, error) {
+		sizes = append(sizes, len(batch))
+		return batch, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sizes, []int{len(src)}) {
+		t.Errorf("batch sizes = %v, want a single batch of %d", sizes, len(src))
+	}
+	if !reflect.DeepEqual(got, src) {
+		t.Errorf("got %v, want %v", got, src)
+	}
+}
+
+func TestMapBatches_Error(t *testing.T) {
+	errBulk := errors.New("bulk api failed")
+	_, err := MapBatches(genUsers(50), 10, 3, func(batch []user) ([]uint, error) {
+		if batch[0].ID == 21 {
+			return nil, errBulk
+		}
+		return nil, nil
+	})
+	if !errors.Is(err, errBulk) {
+		t.Errorf("err = %v, want %v", err, errBulk)
+	}
+	// インデックスはバッチ番号
+	var ie IndexedError
+	if !errors.As(err, &ie) || ie.Index != 2 {
+		t.Errorf("err = %#v, want IndexedError at batch 2", err)
+	}
+}
+
+func TestGroupByConcurrent_MatchesSerial(t *testing.T) {
+	src := genUsers(10007)
+	serial := GroupBy(src, userCity)
+
+	for _, workers := range []int{0, 1, 3, 8} {
+		got := GroupByConcurrent(src, workers, userCity)
+		if len(got) != len(serial) {
+			t.Fatalf("workers=%d: %d groups, want %d", workers, len(got), len(serial))
+		}
+		for city, want := range serial {
+			ids := func(us []user) []uint {
+				out := make([]uint, len(us))
+				for i, u := range us {
+					out[i] = u.ID
+				}
+				return out
+			}
+			if !EqualAsSets(ids(got[city]), ids(want)) || len(got[city]) != len(want) {
+				t.Errorf("workers=%d: group %s differs from serial result", workers, city)
+			}
+		}
+	}
+
+	if got := GroupByConcurrent([]user(nil), 4, userCity); len(got) != 0 {
+		t.Errorf("empty input: got %v", got)
+	}
+}
+
+func TestIndexRanges(t *testing.T) {
+	for _, tt := range []struct{ length, parts, wantParts int }{
+		{10, 3, 3},
+		{9, 3, 3},
+		{2, 5, 2},
+		{1, 1, 1},
+		{100000, 7, 7},
+	} {
+		got := IndexRanges(tt.length, tt.parts)
+		if len(got) != tt.wantParts {
+			t.Fatalf("IndexRanges(%d, %d): %d ranges, want %d", tt.length, tt.parts, len(got), tt.wantParts)
+		}
+		next := 0
+		minLen, maxLen := tt.length, 0
+		for _, r := range got {
+			if r[0] != next || r[1] <= r[0] {
+				t.Fatalf("IndexRanges(%d, %d) = %v: not contiguous", tt.length, tt.parts, got)
+			}
+			minLen, maxLen = min(minLen, r[1]-r[0]), max(maxLen, r[1]-r[0])
+			next = r[1]
+		}
+		if next != tt.length {
+			t.Errorf("IndexRanges(%d, %d) = %v: covers [0,%d)", tt.length, tt.parts, got, next)
+		}
+		if maxLen-minLen > 1 {
+			t.Errorf("IndexRanges(%d, %d) = %v: uneven ranges", tt.length, tt.parts, got)
+		}
+	}
+
+	if got := IndexRanges(0, 4); got != nil {
+		t.Errorf("length 0: got %v, want nil", got)
+	}
+	if got := IndexRanges(10, 0); got != nil {
+		t.Errorf("parts 0: got %v, want nil", got)
+	}
+}
+
+func TestParallelReduce_MatchesReduce(t *testing.T) {
+	src := genUsers(100003)
+	sumAge := func(acc int, u user) int { return acc + int(u.Age) }
+	add := func(a, b int) int { return a + b }
+	want := Reduce(src, 0, sumAge)
+
+	for _, workers := range []int{0, 1, 4, 13} {
+		if got := ParallelReduce(src, workers, 0, sumAge, add); got != want {
+			t.Errorf("workers=%d: got %d, want %d", workers, got, want)
+		}
+	}
+
+	// 結合的だが可換でない combine でも区間順にまとめる
+	joinNames := func(acc string, u user) string { return acc + u.Name[len(u.Name)-1:] }
+	concat := func(a, b string) string { return a + b }
+	if got, want := ParallelReduce(src[:1000], 7, "", joinNames, concat), Reduce(src[:1000], "", joinNames); got != want {
+		t.Error("ordered combine differs from serial Reduce")
+	}
+
+	if got := ParallelReduce([]user(nil), 4, 42, sumAge, add); got != 42 {
+		t.Errorf("empty: got %d, want init 42", got)
+	}
+}
@@ -0,0 +1,127 @@
+package slicepat
+
+// StableUnique は重複を除いた新しいスライスと、取り除いた要素数を返す。
+// 各値は最初に現れた位置に残り、結果の並びは src での初出順と一致する。
+func StableUnique[T comparable](src []T) (result []T, removed int) {
+	return StableUniqueBy(src, func(v T) T { return v })
+}
+
+// StableUniqueBy は key が同じ要素を重複とみなす StableUnique。
+// 同じキーの要素が複数あれば最初のものを残す。
+func StableUniqueBy[T any, K comparable](src []T, key func(T) K) (result []T, removed int) {
+	seen := make(map[K]struct{}, len(src))
+	result = make([]T, 0, len(src))
+	for _, v := range src {
+		k := key(v)
+		if _, ok := seen[k]; ok {
+			removed++
+			continue
+		}
+		seen[k] = struct{}{}
+		result = append(result, v)
+	}
+	return result, removed
+}
+
+// DedupWindow は、入力上で直前 window 個以内に同じ値が現れている要素を取り除いた新しいスライスを返す。
+// それより前に現れただけの値は残す。全体の集合を持たずにストリーム中の近接した重複を除く用途。
+// 取り除いた要素も「現れた」として数える。window が 0 以下なら何も除かずにコピーを返す。
+func DedupWindow[T comparable](src []T, window int) []T {
+	out := make([]T, 0, len(src))
+	if window <= 0 {
+		return append(out, src...)
+	}
+	lastSeen := make(map[T]int)
+	for i, v := range src {
+		j, ok := lastSeen[v]
+		lastSeen[v] = i
+		if ok && i-j <= window {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// CountDistinct は src に含まれる値の種類数を返す。
+func CountDistinct[T comparable](src []T) int {
+	return CountDistinctBy(src, func(v T) T { return v })
+}
+
+// CountDistinctBy は key の値の種類数を返す（ユーザーの都市の数など）。
+// 重複を除いたスライス自体は作らない。
+func CountDistinctBy[T any, K comparable](src []T, key func(T) K) int {
+	seen := make(map[K]struct{})
+	for _, v := range src {
+		seen[key(v)] = struct{}{}
+	}
+	return len(seen)
+}
+
+// MergeByKey は a と b を連結した並びを key ごとに1要素へまとめる。
+// 同じキーの要素が複数あれば、先に現れたものを x、後のものを y として merge(x, y) で畳み込む。
+// 結果はキーが最初に現れた順に並ぶ。a も b も変更しない。
+func MergeByKey[K comparable, T any](a, b []T, key func(T) K, merge func(x, y T) T) []T {
+	pos := make(map[K]int, len(a)+len(b))
+	out := make([]T, 0, len(a)+len(b))
+	for _, src := range [][]T{a, b} {
+		for _, v := range src {
+			k := key(v)
+			if i, ok := pos[k]; ok {
+				out[i] = merge(out[i], v)
+				continue
+			}
+			pos[k] = len(out)
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// DedupeBy は key が同じ要素のうち最初のものだけを残した新しいスライスを返す。順序は保ち、s は変更しない。
+// 取り除いた件数も必要なら StableUniqueBy を使う。
+func DedupeBy[T any, K comparable](s []T, key func(T) K) []T {
+	out, _ := StableUniqueBy(s, key)
+	return out
+}
+
+// DedupePtrBy は nil 要素を取り除き、残りを key で重複除去（最初のものを残す）したスライスを返す。
+// ポインタはコピーせずそのまま共有するので、結果経由の変更は ps の要素にも反映される。
+// key に nil は渡らない。JSON 出力前の前処理向け。
+func DedupePtrBy[T any, K comparable](ps []*T, key func(*T) K) []*T {
+	seen := make(map[K]struct{}, len(ps))
+	out := make([]*T, 0, len(ps))
+	for _, p := range ps {
+		if p == nil {
+			continue
+		}
+		k := key(p)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, p)
+	}
+	return out
+}
+
+// DedupAdjacentInPlace は隣り合う重複をその場で1つにまとめ、短くなったスライスを返す。
+// src の配列を再利用するのでアロケーションしない。空いた末尾はゼロ値で埋め、古い値を参照し続けないようにする。
+// ソート済みの大きなスライスなら、これで全体の重複除去になる。
+func DedupAdjacentInPlace[T comparable](src []T) []T {
+	if len(src) == 0 {
+		return src
+	}
+	n := 1
+	for i := 1; i < len(src); i++ {
+		if src[i] != src[n-1] {
+			src[n] = src[i]
+			n++
+		}
+	}
+	var zero T
+	for i := n; i < len(src); i++ {
+		src[i] = zero
+	}
+	return src[:n]
+}
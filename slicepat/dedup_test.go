@@ -0,0 +1,155 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStableUnique(t *testing.T) {
+	src := []string{"b", "a", "b", "c", "a", "d", "c", "b"}
+	got, removed := StableUnique(src)
+	if want := []string{"b", "a", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if removed != 4 {
+		t.Errorf("removed = %d, want 4", removed)
+	}
+
+	got, removed = StableUnique([]string(nil))
+	if got == nil || len(got) != 0 || removed != 0 {
+		t.Errorf("empty input: got %v (nil=%v), removed %d", got, got == nil, removed)
+	}
+}
+
+func TestStableUniqueBy(t *testing.T) {
+	src := []user{
+		{ID: 1, City: "Sendai"},
+		{ID: 2, City: "Tokyo"},
+		{ID: 3, City: "Sendai"},
+		{ID: 4, City: "Osaka"},
+		{ID: 5, City: "Tokyo"},
+	}
+	got, removed := StableUniqueBy(src, func(u user) string { return u.City })
+	var ids []uint
+	for _, u := range got {
+		ids = append(ids, u.ID)
+	}
+	if want := []uint{1, 2, 4}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+}
+
+func TestDedupWindow(t *testing.T) {
+	src := []string{"a", "b", "a", "c", "d", "e", "a", "a"}
+	tests := []struct {
+		window int
+		want   []string
+	}{
+		// 2つ目の a は直前2個以内、7つ目の a は前の a から4個離れているので残る
+		{2, []string{"a", "b", "c", "d", "e", "a"}},
+		{4, []string{"a", "b", "c", "d", "e"}},
+		{1, []string{"a", "b", "a", "c", "d", "e", "a"}},
+		{0, src},
+	}
+	for _, tt := range tests {
+		if got := DedupWindow(src, tt.window); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("window=%d: got %v, want %v", tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestCountDistinct(t *testing.T) {
+	if got := CountDistinct([]int{3, 1, 3, 2, 1, 3}); got != 3 {
+		t.Errorf("got %d, want 3", got)
+	}
+	if got := CountDistinct([]int(nil)); got != 0 {
+		t.Errorf("empty: got %d, want 0", got)
+	}
+	if got := CountDistinctBy(demoUsers, userCity); got != 4 {
+		t.Errorf("distinct cities = %d, want 4", got)
+	}
+}
+
+func TestMergeByKey(t *testing.T) {
+	type visits struct {
+		City  string
+		Count int
+	}
+	a := []visits{{"Sendai", 1}, {"Tokyo", 2}, {"Sendai", 3}}
+	b := []visits{{"Osaka", 4}, {"Tokyo", 5}}
+	sum := func(x, y visits) visits { x.Count += y.Count; return x }
+
+	got := MergeByKey(a, b, func(v visits) string { return v.City }, sum)
+	want := []visits{{"Sendai", 4}, {"Tokyo", 7}, {"Osaka", 4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if a[0].Count != 1 || b[1].Count != 5 {
+		t.Error("inputs were modified")
+	}
+}
+
+func TestDedupeBy(t *testing.T) {
+	src := []user{
+		{ID: 1, Email: "a@example.com"},
+		{ID: 2, Email: "b@example.com"},
+		{ID: 3, Email: "a@example.com"},
+		{ID: 4, Email: "c@example.com"},
+		{ID: 5, Email: "b@example.com"},
+		{ID: 6, Email: "c@example.com"},
+	}
+	orig := append([]user(nil), src...)
+
+	got := DedupeBy(src, func(u user) string { return u.Email })
+	var ids []uint
+	for _, u := range got {
+		ids = append(ids, u.ID)
+	}
+	if want := []uint{1, 2, 4}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v (first wins)", ids, want)
+	}
+	if !reflect.DeepEqual(src, orig) {
+		t.Error("input was modified")
+	}
+}
+
+func TestDedupePtrBy(t *testing.T) {
+	a1 := &user{ID: 1, Email: "a@example.com"}
+	b := &user{ID: 2, Email: "b@example.com"}
+	a2 := &user{ID: 3, Email: "a@example.com"}
+	c := &user{ID: 4, Email: "c@example.com"}
+	src := []*user{nil, a1, b, nil, a2, c, b, nil}
+
+	got := DedupePtrBy(src, func(u *user) string { return u.Email })
+	if len(got) != 3 || got[0] != a1 || got[1] != b || got[2] != c {
+		t.Errorf("got %v, want [a1 b c]", got)
+	}
+	for i, p := range got {
+		if p == nil {
+			t.Errorf("got[%d] is nil", i)
+		}
+	}
+}
+
+func TestDedupAdjacentInPlace(t *testing.T) {
+	src := []string{"a", "a", "b", "c", "c", "c", "a", "d", "d"}
+	got := DedupAdjacentInPlace(src)
+	if want := []string{"a", "b", "c", "a", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if &got[0] != &src[0] {
+		t.Error("expected src backing array to be reused")
+	}
+	for i, v := range src[len(got):] {
+		if v != "" {
+			t.Errorf("tail[%d] = %q, want zero value", i, v)
+		}
+	}
+
+	if got := DedupAdjacentInPlace([]int(nil)); len(got) != 0 {
+		t.Errorf("empty: got %v", got)
+	}
+}
@@ -0,0 +1,125 @@
+package slicepat
+
+import "fmt"
+
+// DiffPtrIdentity は2つのポインタスライスを値ではなくアドレスで比較し、
+// next にだけあるポインタを added、prev にだけあるポインタを removed として返す。
+// 中身が同じでも別のオブジェクトなら別物として扱うので、キャッシュの照合などで
+// 「どのオブジェクトが出入りしたか」を知りたいときに使う。
+// nil 要素は無視し、結果はそれぞれ元のスライスでの出現順に並ぶ。
+func DiffPtrIdentity[T any](prev, next []*T) (added, removed []*T) {
+	inPrev := make(map[*T]struct{}, len(prev))
+	for _, p := range prev {
+		if p != nil {
+			inPrev[p] = struct{}{}
+		}
+	}
+	inNext := make(map[*T]struct{}, len(next))
+	for _, p := range next {
+		if p == nil {
+			continue
+		}
+		if _, dup := inNext[p]; dup {
+			continue
+		}
+		inNext[p] = struct{}{}
+		if _, ok := inPrev[p]; !ok {
+			added = append(added, p)
+		}
+	}
+	for _, p := range prev {
+		if p == nil {
+			continue
+		}
+		if _, ok := inNext[p]; !ok {
+			removed = append(removed, p)
+			inNext[p] = struct{}{} // 重複して報告しない
+		}
+	}
+	return added, removed
+}
+
+// EditKind は編集スクリプトの操作の種類。
+type EditKind int
+
+const (
+	// EditKeep は元の要素をそのまま残す。
+	EditKeep EditKind = iota
+	// EditDelete は元の要素を削除する。
+	EditDelete
+	// EditInsert は新しい要素を挿入する。
+	EditInsert
+)
+
+// EditOp は編集スクリプトの1操作。Value は対象の要素。
+type EditOp[T any] struct {
+	Kind  EditKind
+	Value T
+}
+
+// DiffOrdered は prev を next に変える最小の編集スクリプト（残す・削除・挿入）を返す。
+// 最長共通部分列（LCS）を動的計画法で求める簡易版で、時間・メモリとも O(len(prev)*len(next))。
+// 移動は削除と挿入の組として表れる。UI で並び順のある一覧の変化を表示する用途向け。
+// 同じ位置で削除と挿入が並ぶ場合は削除を先に出す。
+func DiffOrdered[T comparable](prev, next []T) []EditOp[T] {
+	n, m := len(prev), len(next)
+	// lcs[i][j] は prev[i:] と next[j:] の LCS の長さ
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if prev[i] == next[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]EditOp[T], 0, n+m-lcs[0][0])
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && prev[i] == next[j]:
+			ops = append(ops, EditOp[T]{Kind: EditKeep, Value: prev[i]})
+			i++
+			j++
+		case i < n && (j == m || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, EditOp[T]{Kind: EditDelete, Value: prev[i]})
+			i++
+		default:
+			ops = append(ops, EditOp[T]{Kind: EditInsert, Value: next[j]})
+			j++
+		}
+	}
+	return ops
+}
+
+// ApplyEditScript は prev に DiffOrdered の編集スクリプト ops を適用した結果を返す。
+// 残す・削除の操作が prev の要素と一致しない場合や、ops が prev を使い切らない場合はエラーを返す。
+func ApplyEditScript[T comparable](prev []T, ops []EditOp[T]) ([]T, error) {
+	out := make([]T, 0, len(prev))
+	i := 0
+	for k, op := range ops {
+		switch op.Kind {
+		case EditInsert:
+			out = append(out, op.Value)
+		case EditKeep, EditDelete:
+			if i >= len(prev) || prev[i] != op.Value {
+				return nil, fmt.Errorf("slicepat: edit op %d does not match source at index %d", k, i)
+			}
+			if op.Kind == EditKeep {
+				out = append(out, prev[i])
+			}
+			i++
+		default:
+			return nil, fmt.Errorf("slicepat: edit op %d has unknown kind %d", k, op.Kind)
+		}
+	}
+	if i != len(prev) {
+		return nil, fmt.Errorf("slicepat: edit script consumed %d of %d source elements", i, len(prev))
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffPtrIdentity(t *testing.T) {
+	shared := &user{ID: 1, Name: "Alice"}
+	gone := &user{ID: 2, Name: "Bob"}
+	lookalike := &user{ID: 2, Name: "Bob"} // 値は gone と同じだが別オブジェクト
+	fresh := &user{ID: 3, Name: "Carol"}
+
+	prev := []*user{shared, gone, nil}
+	next := []*user{nil, shared, lookalike, fresh, fresh}
+
+	added, removed := DiffPtrIdentity(prev, next)
+	if len(added) != 2 || added[0] != lookalike || added[1] != fresh {
+		t.Errorf("added = %v, want [lookalike fresh]", added)
+	}
+	if len(removed) != 1 || removed[0] != gone {
+		t.Errorf("removed = %v, want [gone]", removed)
+	}
+
+	added, removed = DiffPtrIdentity(next, next)
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("same slice: added=%v removed=%v, want none", added, removed)
+	}
+}
+
+func TestDiffOrdered_RoundTrip(t *testing.T) {
+	tests := []struct {
+		prev, next []string
+	}{
+		{[]string{"a", "b", "c", "d"}, []string{"b", "a", "c", "d"}},
+		{[]string{"a", "b", "c"}, []string{"c", "a", "b", "e"}},
+		{nil, []string{"x", "y"}},
+		{[]string{"x", "y"}, nil},
+		{[]string{"a", "b"}, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		ops := DiffOrdered(tt.prev, tt.next)
+		got, err := ApplyEditScript(tt.prev, ops)
+		if err != nil {
+			t.Fatalf("%v -> %v: %v", tt.prev, tt.next, err)
+		}
+		if len(got) != len(tt.next) || (len(got) > 0 && !reflect.DeepEqual(got, tt.next)) {
+			t.Errorf("%v -> %v: applied script gives %v", tt.prev, tt.next, got)
+		}
+	}
+}
+
+func TestDiffOrdered_Minimal(t *testing.T) {
+	// b を先頭に移動: a を削除して c の前に入れ直すのが最小（変更2操作）
+	ops := DiffOrdered([]string{"a", "b", "c"}, []string{"b", "a", "c"})
+	changes := 0
+	for _, op := range ops {
+		if op.Kind != EditKeep {
+			changes++
+		}
+	}
+	if changes != 2 {
+		t.Errorf("ops = %v, want 2 non-keep operations", ops)
+	}
+}
+
+func TestApplyEditScript_Mismatch(t *testing.T) {
+	ops := []EditOp[string]{{Kind: EditKeep, Value: "z"}}
+	if _, err := ApplyEditScript([]string{"a"}, ops); err == nil {
+		t.Error("expected error for mismatched keep")
+	}
+	if _, err := ApplyEditScript([]string{"a", "b"}, []EditOp[string]{{Kind: EditKeep, Value: "a"}}); err == nil {
+		t.Error("expected error for unconsumed source")
+	}
+}
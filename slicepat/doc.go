@@ -0,0 +1,7 @@
+// Package slicepat は、リポジトリ内のデモやベンチマークで手書きしている
+// スライス操作（値スライス / 要素ポインタのスライス）を汎用関数として切り出したものです。
+//
+// 各関数は特に断りがない限り入力スライスを変更せず、新しいスライスを返します。
+// ポインタ要素を扱う関数では、要素を共有する（浅い）のか複製する（深い）のかを
+// 個々のドキュメントに明記しています。
+package slicepat
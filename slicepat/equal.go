@@ -0,0 +1,81 @@
+package slicepat
+
+import (
+	"math"
+	"reflect"
+)
+
+// EqualAsSets は a と b を集合として比較する。順序も重複の個数も無視し、
+// 含まれる値の種類が同じなら true を返す（[a a b] と [a b] は等しい）。
+func EqualAsSets[T comparable](a, b []T) bool {
+	inA := make(map[T]struct{}, len(a))
+	for _, v := range a {
+		inA[v] = struct{}{}
+	}
+	inB := make(map[T]struct{}, len(b))
+	for _, v := range b {
+		if _, ok := inA[v]; !ok {
+			return false
+		}
+		inB[v] = struct{}{}
+	}
+	return len(inA) == len(inB)
+}
+
+// EqualApprox は a と b の各要素について value の値の差が eps 以内なら true を返す。
+// 長さが違えば false。丸め誤差のある計算済みスコアなど、浮動小数点の項目の比較に使う。
+// NaN はどの値とも（NaN 同士でも）等しくないとみなす。同符号の無限大同士は等しい。
+func EqualApprox[T any](a, b []T, value func(T) float64, eps float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		x, y := value(a[i]), value(b[i])
+		// NaN を含むと比較はすべて false になるので、「eps 以内でない」で判定する
+		if x != y && !(math.Abs(x-y) <= eps) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualDeepPtr は2つのポインタスライスを、ポインタが指す値同士の reflect.DeepEqual で比較する。
+// 同じ位置がどちらも nil なら等しく、片方だけ nil なら異なるとみなす。長さが違えば false。
+// ディープコピーした結果の中身が元と一致するかを確かめる用途。
+func EqualDeepPtr[T any](a, b []*T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		switch {
+		case a[i] == nil && b[i] == nil:
+			continue
+		case a[i] == nil || b[i] == nil:
+			return false
+		case !reflect.DeepEqual(*a[i], *b[i]):
+			return false
+		}
+	}
+	return true
+}
+
+// FirstDiff は a と b が最初に食い違う位置と、両者が等しいかを返す。
+// 共通部分が一致して長さだけ違う場合は短い方の長さ（はみ出した最初の位置）を返す。
+// 等しければ (-1, true)。テストでコピーがどこから食い違ったかを特定するのに使う。
+func FirstDiff[T comparable](a, b []T) (index int, equal bool) {
+	return FirstDiffFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// FirstDiffFunc は要素の比較に eq を使う FirstDiff。
+func FirstDiffFunc[T any](a, b []T, eq func(x, y T) bool) (index int, equal bool) {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if !eq(a[i], b[i]) {
+			return i, false
+		}
+	}
+	if len(a) != len(b) {
+		return n, false
+	}
+	return -1, true
+}
@@ -0,0 +1,115 @@
+package slicepat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqualAsSets(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want bool
+	}{
+		{[]string{"a", "a", "b"}, []string{"a", "b"}, true},
+		{[]string{"b", "a"}, []string{"a", "b", "b"}, true},
+		{[]string{"a", "b"}, []string{"a", "c"}, false},
+		{[]string{"a", "b"}, []string{"a"}, false},
+		{nil, []string{}, true},
+		// 重複の個数が違っても値の種類が同じなら等しい（多重集合としては異なる）
+		{[]string{"a", "b", "b", "b"}, []string{"b", "a", "a"}, true},
+		{[]string{"a", "b", "c"}, []string{"c", "a", "a"}, false},
+	}
+	for _, tt := range tests {
+		if got := EqualAsSets(tt.a, tt.b); got != tt.want {
+			t.Errorf("EqualAsSets(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestEqualApprox(t *testing.T) {
+	type scored struct {
+		ID    uint
+		Score float64
+	}
+	score := func(s scored) float64 { return s.Score }
+	a := []scored{{1, 0.5}, {2, 1.25}, {3, 10}}
+
+	inside := []scored{{1, 0.5009}, {2, 1.2491}, {3, 10}}
+	if !EqualApprox(a, inside, score, 1e-3) {
+		t.Error("values within eps should be equal")
+	}
+	outside := []scored{{1, 0.5}, {2, 1.2515}, {3, 10}}
+	if EqualApprox(a, outside, score, 1e-3) {
+		t.Error("values outside eps should differ")
+	}
+	if EqualApprox(a, a[:2], score, 1) {
+		t.Error("different lengths should differ")
+	}
+
+	nan := []scored{{1, 0.5}, {2, math.NaN()}, {3, 10}}
+	if EqualApprox(a, nan, score, 1e-3) || EqualApprox(nan, a, score, 1e-3) {
+		t.Error("NaN should differ from any value")
+	}
+	if EqualApprox(nan, nan, score, 1e-3) {
+		t.Error("NaN should differ from NaN")
+	}
+	inf := []scored{{1, math.Inf(1)}}
+	if !EqualApprox(inf, inf, score, 1e-3) {
+		t.Error("equal infinities should be equal")
+	}
+}
+
+func TestEqualDeepPtr(t *testing.T) {
+	src := genPtrUsers(5)
+	src[2] = nil
+	copied := DeepCopyShared(src)
+
+	if !EqualDeepPtr(src, copied) {
+		t.Error("deep copy should be equal by content")
+	}
+	copied[0].Name = "changed"
+	if EqualDeepPtr(src, copied) {
+		t.Error("modified copy should differ")
+	}
+
+	if EqualDeepPtr([]*user{nil}, []*user{{}}) {
+		t.Error("nil vs non-nil should differ")
+	}
+	if EqualDeepPtr(src, src[:4]) {
+		t.Error("different lengths should differ")
+	}
+}
+
+func TestFirstDiff(t *testing.T) {
+	tests := []struct {
+		a, b      []int
+		wantIndex int
+		wantEqual bool
+	}{
+		{[]int{1, 2, 3}, []int{1, 2, 3}, -1, true},
+		{nil, []int{}, -1, true},
+		{[]int{1, 2, 3}, []int{1, 5, 3}, 1, false},
+		{[]int{1, 2}, []int{1, 2, 3}, 2, false},
+		{[]int{1, 2, 3}, []int{1}, 1, false},
+	}
+	for _, tt := range tests {
+		index, equal := FirstDiff(tt.a, tt.b)
+		if index != tt.wantIndex || equal != tt.wantEqual {
+			t.Errorf("FirstDiff(%v, %v) = %d, %v, want %d, %v", tt.a, tt.b, index, equal, tt.wantIndex, tt.wantEqual)
+		}
+	}
+}
+
+func TestFirstDiffFunc(t *testing.T) {
+	a := ToPtrSlice(demoUsers)
+	b := DeepCopyPtrSlice(a)
+	sameUser := func(x, y *user) bool { return *x == *y }
+
+	if index, equal := FirstDiffFunc(a, b, sameUser); !equal || index != -1 {
+		t.Errorf("deep copy: got %d, %v, want -1, true", index, equal)
+	}
+	b[4].City = "Nagoya"
+	if index, equal := FirstDiffFunc(a, b, sameUser); equal || index != 4 {
+		t.Errorf("modified copy: got %d, %v, want 4, false", index, equal)
+	}
+}
@@ -0,0 +1,33 @@
+package slicepat
+
+import (
+	"errors"
+	"fmt"
+)
+
+// IndexedError はスライスの何番目の要素で起きたエラーかを保持する。
+type IndexedError struct {
+	Index int
+	Err   error
+}
+
+func (e IndexedError) Error() string {
+	return fmt.Sprintf("index %d: %v", e.Index, e.Err)
+}
+
+func (e IndexedError) Unwrap() error {
+	return e.Err
+}
+
+// JoinIndexedErrors は要素ごとのエラーを errors.Join で1つにまとめる。
+// 各エラーのメッセージには "index N: " が前置される。Err が nil のものは無視し、
+// 残りがなければ nil を返す。
+func JoinIndexedErrors(errs []IndexedError) error {
+	joined := make([]error, 0, len(errs))
+	for _, e := range errs {
+		if e.Err != nil {
+			joined = append(joined, e)
+		}
+	}
+	return errors.Join(joined...)
+}
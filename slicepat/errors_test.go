@@ -0,0 +1,42 @@
+package slicepat
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJoinIndexedErrors(t *testing.T) {
+	errEmail := errors.New("invalid email")
+	err := JoinIndexedErrors([]IndexedError{
+		{Index: 2, Err: errEmail},
+		{Index: 5, Err: nil},
+		{Index: 7, Err: errors.New("age out of range")},
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	msg := err.Error()
+	for _, want := range []string{"index 2: invalid email", "index 7: age out of range"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message %q does not contain %q", msg, want)
+		}
+	}
+	if strings.Contains(msg, "index 5") {
+		t.Errorf("message %q contains nil entry", msg)
+	}
+	if !errors.Is(err, errEmail) {
+		t.Error("errors.Is should find the wrapped error")
+	}
+	var ie IndexedError
+	if !errors.As(err, &ie) || ie.Index != 2 {
+		t.Errorf("errors.As = %v, want index 2", ie)
+	}
+
+	if err := JoinIndexedErrors(nil); err != nil {
+		t.Errorf("nil input: got %v, want nil", err)
+	}
+	if err := JoinIndexedErrors([]IndexedError{{Index: 0}}); err != nil {
+		t.Errorf("only nil errors: got %v, want nil", err)
+	}
+}
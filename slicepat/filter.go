@@ -0,0 +1,60 @@
+package slicepat
+
+// Filter は pred が true を返した要素だけを順に集めた新しいスライスを返す。
+// 要素はコピーせずにそのまま入れるので、[]*T に使うと結果は s と同じポインタを共有する
+// （結果経由の変更が元の要素にも伝わる）。独立させたい場合は FilterDeepCopy を使う。
+func Filter[T any](s []T, pred func(T) bool) []T {
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		if pred(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// FilterDeepCopy は nil でなく pred が true を返した要素を、値をコピーした新しいポインタとして集める。
+// 結果は ps と1つもポインタを共有しないので、絞り込んだ結果を外部に公開しても元データは守られる。
+// pred に nil は渡らない。
+func FilterDeepCopy[T any](ps []*T, pred func(*T) bool) []*T {
+	out := make([]*T, 0, len(ps))
+	for _, p := range ps {
+		if p == nil || !pred(p) {
+			continue
+		}
+		cp := *p
+		out = append(out, &cp)
+	}
+	return out
+}
+
+// And は preds がすべて true のときに true を返す述語を作る（都市かつ年齢での絞り込みなど）。
+// 先頭から評価し、false になった時点で残りは呼ばない。preds が空なら常に true を返す。
+func And[T any](preds ...func(T) bool) func(T) bool {
+	return func(v T) bool {
+		for _, p := range preds {
+			if !p(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or は preds のいずれかが true のときに true を返す述語を作る。
+// 先頭から評価し、true になった時点で残りは呼ばない。preds が空なら常に false を返す。
+func Or[T any](preds ...func(T) bool) func(T) bool {
+	return func(v T) bool {
+		for _, p := range preds {
+			if p(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not は pred の結果を反転した述語を返す。
+func Not[T any](pred func(T) bool) func(T) bool {
+	return func(v T) bool { return !pred(v) }
+}
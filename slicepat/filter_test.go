@@ -0,0 +1,79 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	got := Filter(demoUsers, func(u user) bool { return u.City == "Sendai" })
+	if want := []string{"Alice", "Eve", "Grace"}; !reflect.DeepEqual(userNames(got), want) {
+		t.Errorf("got %v, want %v", userNames(got), want)
+	}
+	if got := Filter(demoUsers, func(user) bool { return false }); got == nil || len(got) != 0 {
+		t.Errorf("no match: got %v, want empty", got)
+	}
+}
+
+func TestFilter_PointerElementsAreShared(t *testing.T) {
+	src := ToPtrSlice(demoUsers)
+	onlySendai := Filter(src, func(u *user) bool { return u.City == "Sendai" })
+
+	onlySendai[0].Name = "Alice-Updated"
+	if src[0].Name != "Alice-Updated" {
+		t.Errorf("src[0].Name = %q, want mutation through filtered slice to be visible", src[0].Name)
+	}
+	if onlySendai[0] != src[0] {
+		t.Error("Filter should pass pointers through unchanged")
+	}
+}
+
+func TestFilterDeepCopy(t *testing.T) {
+	src := ToPtrSlice(demoUsers)
+	src = append(src, nil)
+	inSendai := func(u *user) bool { return u.City == "Sendai" }
+
+	got := FilterDeepCopy(src, inSendai)
+	if want := []string{"Alice", "Eve", "Grace"}; !reflect.DeepEqual(userNames(ToValueSlice(got)), want) {
+		t.Errorf("got %v, want %v", userNames(ToValueSlice(got)), want)
+	}
+
+	shared := map[*user]bool{}
+	for _, p := range src {
+		shared[p] = true
+	}
+	for i, p := range got {
+		if shared[p] {
+			t.Errorf("got[%d] shares a pointer with the input", i)
+		}
+	}
+
+	got[0].Name = "Alice-DeepCopied"
+	if src[0].Name != "Alice" {
+		t.Errorf("src[0].Name = %q, want unchanged", src[0].Name)
+	}
+}
+
+func TestPredicateCombinators(t *testing.T) {
+	inSendai := func(u user) bool { return u.City == "Sendai" }
+	over20 := func(u user) bool { return u.Age >= 20 }
+	inTokyo := func(u user) bool { return u.City == "Tokyo" }
+
+	tests := []struct {
+		name string
+		pred func(user) bool
+		want []string
+	}{
+		{"And", And(inSendai, over20), []string{"Alice", "Eve"}},
+		{"Or", Or(inSendai, inTokyo), []string{"Alice", "Carol", "Eve", "Frank", "Grace"}},
+		{"Not", Not(over20), []string{"Grace"}},
+		{"nested", And(Or(inSendai, inTokyo), Not(over20)), []string{"Grace"}},
+		{"empty And", And[user](), []string{"Alice", "Bob", "Carol", "Dave", "Eve", "Frank", "Grace"}},
+		{"empty Or", Or[user](), []string{}},
+	}
+	for _, tt := range tests {
+		if got := userNames(Filter(demoUsers, tt.pred)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
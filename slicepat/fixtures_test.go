@@ -0,0 +1,33 @@
+package slicepat
+
+import "strconv"
+
+// テスト用のユーザー型（ルートパッケージの User と同じ形）
+type user struct {
+	ID    uint
+	Name  string
+	Age   uint
+	Email string
+	City  string
+}
+
+func newUser(i int) user {
+	return user{
+		ID:    uint(i + 1),
+		Name:  "User_" + strconv.Itoa(i),
+		Age:   uint(18 + (i % 50)),
+		Email: "user" + strconv.Itoa(i) + "@example.com",
+		City:  "City" + strconv.Itoa(i%10),
+	}
+}
+
+func genUsers(n int) []user {
+	return GenerateSlice(n, newUser)
+}
+
+func genPtrUsers(n int) []*user {
+	return GenerateSlice(n, func(i int) *user {
+		u := newUser(i)
+		return &u
+	})
+}
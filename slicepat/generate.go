@@ -0,0 +1,32 @@
+package slicepat
+
+import "sync"
+
+// GenerateSlice は gen(0) から gen(n-1) までを順に並べた長さ n のスライスを返す。
+// ベンチマークやテストのデータ生成に使う。
+func GenerateSlice[T any](n int, gen func(i int) T) []T {
+	out := make([]T, n)
+	for i := range out {
+		out[i] = gen(i)
+	}
+	return out
+}
+
+// GenerateParallel は GenerateSlice を workers 個のゴルーチンで並行に行う。
+// 各ゴルーチンは連続したインデックス範囲を受け持ち、インデックス位置に直接書き込むので、
+// gen が i だけで決まる限り結果は GenerateSlice と同じになる。workers が 0 以下なら GOMAXPROCS を使う。
+func GenerateParallel[T any](n, workers int, gen func(i int) T) []T {
+	out := make([]T, n)
+	var wg sync.WaitGroup
+	for _, r := range IndexRanges(n, workerCount(workers, n)) {
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				out[i] = gen(i)
+			}
+		}(r[0], r[1])
+	}
+	wg.Wait()
+	return out
+}
@@ -0,0 +1,24 @@
+package slicepat
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestGenerateParallel_MatchesSerial(t *testing.T) {
+	gen := func(i int) user {
+		return user{ID: uint(i + 1), Name: "User_" + strconv.Itoa(i), City: "City" + strconv.Itoa(i%10)}
+	}
+	for _, n := range []int{0, 1, 7, 1000, 10001} {
+		serial := GenerateSlice(n, gen)
+		if len(serial) != n {
+			t.Fatalf("n=%d: len = %d", n, len(serial))
+		}
+		for _, workers := range []int{0, 1, 3, 16} {
+			if got := GenerateParallel(n, workers, gen); !reflect.DeepEqual(got, serial) {
+				t.Errorf("n=%d workers=%d: parallel result differs from serial", n, workers)
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package slicepat
+
+import (
+	"sort"
+	"time"
+)
+
+// ValueCounts は field が返す値ごとの出現回数を数える（都市ごとのユーザー数など）。
+func ValueCounts[T any](src []T, field func(T) string) map[string]int {
+	counts := make(map[string]int)
+	for _, v := range src {
+		counts[field(v)]++
+	}
+	return counts
+}
+
+// TopValues は field の値を出現回数の多い順に最大 n 個返す。
+// 回数が同じ値は辞書順に並べるので結果は常に同じになる。
+func TopValues[T any](src []T, field func(T) string, n int) []string {
+	counts := ValueCounts(src, field)
+	values := make([]string, 0, len(counts))
+	for v := range counts {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] > counts[values[j]]
+		}
+		return values[i] < values[j]
+	})
+	if n < 0 {
+		n = 0
+	}
+	if len(values) > n {
+		values = values[:n]
+	}
+	return values
+}
+
+// MapGroups はグループごとのスライスを f で集約した値の map を返す（都市ごとの件数や平均など）。
+// グループ化した後の「グループごとの reduce」の部分。
+func MapGroups[K comparable, T, U any](groups map[K][]T, f func(K, []T) U) map[K]U {
+	out := make(map[K]U, len(groups))
+	for k, vs := range groups {
+		out[k] = f(k, vs)
+	}
+	return out
+}
+
+// FieldChunk は ChunkByField が返す、同じキーが連続する区間。
+type FieldChunk[K comparable, T any] struct {
+	Key   K
+	Items []T
+}
+
+// ChunkByField は src を field の値が変わる位置で区切り、キー付きの区間の列を返す。
+// 都市でソート済みのユーザーなら、都市ごとの連続した塊がキー付きで得られる。
+// 離れた位置に同じキーが再び現れれば別の区間になる（GroupBy とは異なる）。
+// Items は src の部分スライス（容量も区間の長さに絞ってある）で、要素はコピーしない。
+func ChunkByField[T any, K comparable](src []T, field func(T) K) []FieldChunk[K, T] {
+	out := []FieldChunk[K, T]{}
+	if len(src) == 0 {
+		return out
+	}
+	// field は重いこともあるので要素ごとに1回だけ呼び、今の塊のキーは変数に持つ
+	start, cur := 0, field(src[0])
+	for i := 1; i < len(src); i++ {
+		k := field(src[i])
+		if k == cur {
+			continue
+		}
+		out = append(out, FieldChunk[K, T]{Key: cur, Items: src[start:i:i]})
+		start, cur = i, k
+	}
+	return append(out, FieldChunk[K, T]{Key: cur, Items: src[start:len(src):len(src)]})
+}
+
+// GroupBy は key の値ごとに要素をまとめた map を返す。グループ内は src の順に並ぶ。
+// 各グループのスライスは append で個別に作るので、あるグループのスライスを変更・追記しても
+// 他のグループや src には影響しない（要素がポインタなら指す先は共有する）。
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	out := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		out[k] = append(out[k], v)
+	}
+	return out
+}
+
+// GroupByPtr は nil 要素を飛ばして GroupBy する。どのグループにも nil は入らず、
+// key に nil は渡らない。グループ内のポインタは ps と同じものを共有する。
+func GroupByPtr[T any, K comparable](ps []*T, key func(*T) K) map[K][]*T {
+	out := make(map[K][]*T)
+	for _, p := range ps {
+		if p == nil {
+			continue
+		}
+		k := key(p)
+		out[k] = append(out[k], p)
+	}
+	return out
+}
+
+// GroupByRange は value の値を edges で区切った区間ごとに要素をまとめる（年齢帯での分類など）。
+// edges は昇順であること。区間番号 i には edges[i-1] <= v < edges[i] の要素が入り、
+// edges[0] 未満は 0、最後の境界以上は len(edges) になる。グループ内は src の順に並ぶ。
+func GroupByRange[T any](src []T, value func(T) float64, edges []float64) map[int][]T {
+	return GroupBy(src, func(v T) int {
+		x := value(v)
+		return sort.Search(len(edges), func(i int) bool { return edges[i] > x })
+	})
+}
+
+// GroupByTime は ts の時刻を bucket 単位に切り捨てた区間ごとに要素をまとめる（イベントの時間別・日別集計など）。
+// 切り捨ては各時刻の Location での壁時計の時刻に対して行うので、24h なら現地の 0 時始まりの日ごとになる
+// （time.Time.Truncate は UTC 基準で切るため、JST の 3 時が前日 9 時始まりの区間に入ってしまう）。
+// キーは区間の開始時刻（ts と同じ Location）で、グループ内は src の順に並ぶ。
+// time.Time を map のキーにするので、同じ瞬間でも Location が異なる時刻は別のキーになる。
+// bucket が 0 以下なら panic する。
+func GroupByTime[T any](src []T, ts func(T) time.Time, bucket time.Duration) map[time.Time][]T {
+	if bucket <= 0 {
+		panic("slicepat: GroupByTime: bucket must be positive")
+	}
+	return GroupBy(src, func(v T) time.Time { return truncateWallClock(ts(v), bucket) })
+}
+
+// truncateWallClock は t の壁時計の時刻を d 単位に切り捨て、t と同じ Location の時刻として返す。
+func truncateWallClock(t time.Time, d time.Duration) time.Time {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC).Truncate(d)
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), t.Location())
+}
+
+// CountMatrix は rowKey と colKey の組み合わせごとの件数を2次元の map で返す
+// （都市 × 年齢層のユーザー数など、ピボットテーブルの元になる集計）。
+// 該当のない組み合わせはキー自体が存在しない（参照すればゼロ値の 0 になる）。
+func CountMatrix[R comparable, C comparable, T any](src []T, rowKey func(T) R, colKey func(T) C) map[R]map[C]int {
+	out := make(map[R]map[C]int)
+	for _, v := range src {
+		r := rowKey(v)
+		row, ok := out[r]
+		if !ok {
+			row = make(map[C]int)
+			out[r] = row
+		}
+		row[colKey(v)]++
+	}
+	return out
+}
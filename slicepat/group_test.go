@@ -0,0 +1,257 @@
+package slicepat
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// デモで使っているユーザーと都市
+var demoUsers = []user{
+	{ID: 1, Name: "Alice", Age: 20, City: "Sendai"},
+	{ID: 2, Name: "Bob", Age: 30, City: "Kanazawa"},
+	{ID: 3, Name: "Carol", Age: 40, City: "Tokyo"},
+	{ID: 4, Name: "Dave", Age: 25, City: "Osaka"},
+	{ID: 5, Name: "Eve", Age: 35, City: "Sendai"},
+	{ID: 6, Name: "Frank", Age: 45, City: "Tokyo"},
+	{ID: 7, Name: "Grace", Age: 19, City: "Sendai"},
+}
+
+func userCity(u user) string { return u.City }
+
+func TestValueCounts(t *testing.T) {
+	want := map[string]int{"Sendai": 3, "Tokyo": 2, "Kanazawa": 1, "Osaka": 1}
+	if got := ValueCounts(demoUsers, userCity); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestTopValues(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{1, []string{"Sendai"}},
+		{3, []string{"Sendai", "Tokyo", "Kanazawa"}},
+		{10, []string{"Sendai", "Tokyo", "Kanazawa", "Osaka"}},
+		{0, []string{}},
+	}
+	for _, tt := range tests {
+		if got := TopValues(demoUsers, userCity, tt.n); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("TopValues(n=%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestMapGroups(t *testing.T) {
+	groups := map[string][]user{}
+	for _, u := range demoUsers {
+		groups[u.City] = append(groups[u.City], u)
+	}
+
+	counts := MapGroups(groups, func(_ string, us []user) int { return len(us) })
+	want := map[string]int{"Sendai": 3, "Tokyo": 2, "Kanazawa": 1, "Osaka": 1}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("got %v, want %v", counts, want)
+	}
+
+	avgAge := MapGroups(groups, func(_ string, us []user) float64 {
+		_, _, mean, _ := Stats(us, userAge)
+		return mean
+	})
+	if avgAge["Tokyo"] != 42.5 {
+		t.Errorf("avg age in Tokyo = %v, want 42.5", avgAge["Tokyo"])
+	}
+}
+
+func TestChunkByField(t *testing.T) {
+	sorted := append([]user(nil), demoUsers...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].City < sorted[j].City })
+
+	chunks := ChunkByField(sorted, userCity)
+	var keys []string
+	var names [][]string
+	for _, c := range chunks {
+		keys = append(keys, c.Key)
+		names = append(names, userNames(c.Items))
+	}
+	if want := []string{"Kanazawa", "Osaka", "Sendai", "Tokyo"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+	wantNames := [][]string{{"Bob"}, {"Dave"}, {"Alice", "Eve", "Grace"}, {"Carol", "Frank"}}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("items = %v, want %v", names, wantNames)
+	}
+
+	// 未ソートなら同じキーでも離れていれば別の塊
+	if got := ChunkByField([]string{"a", "a", "b", "a"}, func(s string) string { return s }); len(got) != 3 {
+		t.Errorf("unsorted: %d chunks, want 3", len(got))
+	}
+	if got := ChunkByField([]user(nil), userCity); got == nil || len(got) != 0 {
+		t.Errorf("empty: got %v, want empty", got)
+	}
+
+	// field は要素ごとに1回だけ呼ぶ
+	calls := 0
+	ChunkByField(sorted, func(u user) string { calls++; return u.City })
+	if calls != len(sorted) {
+		t.Errorf("field called %d times, want %d", calls, len(sorted))
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := GroupBy(demoUsers, userCity)
+	counts := map[string]int{}
+	for city, us := range groups {
+		counts[city] = len(us)
+	}
+	want := map[string]int{"Sendai": 3, "Tokyo": 2, "Kanazawa": 1, "Osaka": 1}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("counts = %v, want %v", counts, want)
+	}
+	if got := userNames(groups["Sendai"]); !reflect.DeepEqual(got, []string{"Alice", "Eve", "Grace"}) {
+		t.Errorf("Sendai = %v, want source order", got)
+	}
+
+	// グループのスライスへの追記が他のグループを壊さない
+	tokyo := append(groups["Tokyo"], user{Name: "Extra"})
+	tokyo[0].Name = "changed"
+	if groups["Sendai"][0].Name != "Alice" || demoUsers[2].Name != "Carol" {
+		t.Error("mutating one group's slice affected other data")
+	}
+}
+
+func TestGroupByPtr(t *testing.T) {
+	src := ToPtrSlice(demoUsers)
+	src = append([]*user{nil}, src...)
+	src = append(src[:4], append([]*user{nil, nil}, src[4:]...)...)
+	src = append(src, nil)
+
+	nonNil := 0
+	for _, p := range src {
+		if p != nil {
+			nonNil++
+		}
+	}
+
+	groups := GroupByPtr(src, func(u *user) string { return u.City })
+	total := 0
+	for city, us := range groups {
+		for _, p := range us {
+			if p == nil {
+				t.Errorf("group %s contains nil", city)
+			}
+		}
+		total += len(us)
+	}
+	if total != nonNil {
+		t.Errorf("sum of group sizes = %d, want %d non-nil elements", total, nonNil)
+	}
+	if len(groups["Sendai"]) != 3 {
+		t.Errorf("len(Sendai) = %d, want 3", len(groups["Sendai"]))
+	}
+}
+
+func TestGroupByRange(t *testing.T) {
+	// 〜19 / 20〜29 / 30〜39 / 40〜
+	bands := GroupByRange(demoUsers, userAge, []float64{20, 30, 40})
+	got := map[int][]string{}
+	for i, us := range bands {
+		got[i] = userNames(us)
+	}
+	want := map[int][]string{
+		0: {"Grace"},
+		1: {"Alice", "Dave"},
+		2: {"Bob", "Eve"},
+		3: {"Carol", "Frank"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// ルートのベンチマークの groupAge と同じ区分
+func groupAge(age uint) string {
+	switch {
+	case age < 20:
+		return "teen"
+	case age < 30:
+		return "20s"
+	case age < 40:
+		return "30s"
+	default:
+		return "40+"
+	}
+}
+
+func TestCountMatrix(t *testing.T) {
+	got := CountMatrix(demoUsers, userCity, func(u user) string { return groupAge(u.Age) })
+	want := map[string]map[string]int{
+		"Sendai":   {"teen": 1, "20s": 1, "30s": 1},
+		"Kanazawa": {"30s": 1},
+		"Tokyo":    {"40+": 2},
+		"Osaka":    {"20s": 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got["Tokyo"]["teen"] != 0 {
+		t.Error("missing cell should read as 0")
+	}
+}
+
+func TestGroupByTime(t *testing.T) {
+	type event struct {
+		Name string
+		At   time.Time
+	}
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	events := []event{
+		{"login", base.Add(5 * time.Minute)},
+		{"view", base.Add(70 * time.Minute)},
+		{"click", base.Add(30 * time.Minute)},
+		{"logout", base.Add(119 * time.Minute)},
+		{"open", base},
+	}
+	got := GroupByTime(events, func(e event) time.Time { return e.At }, time.Hour)
+
+	names := func(es []event) []string {
+		out := make([]string, len(es))
+		for i, e := range es {
+			out[i] = e.Name
+		}
+		return out
+	}
+	want := map[time.Time][]string{
+		base:                {"login", "click", "open"},
+		base.Add(time.Hour): {"view", "logout"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d buckets, want %d", len(got), len(want))
+	}
+	for k, w := range want {
+		if g := names(got[k]); !reflect.DeepEqual(g, w) {
+			t.Errorf("bucket %v: got %v, want %v", k, g, w)
+		}
+	}
+}
+
+func TestGroupByTime_LocalDay(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	times := []time.Time{
+		time.Date(2024, 5, 1, 3, 0, 0, 0, jst),
+		time.Date(2024, 5, 1, 23, 0, 0, 0, jst),
+		time.Date(2024, 5, 2, 1, 0, 0, 0, jst),
+	}
+	got := GroupByTime(times, func(t time.Time) time.Time { return t }, 24*time.Hour)
+
+	may1, may2 := time.Date(2024, 5, 1, 0, 0, 0, 0, jst), time.Date(2024, 5, 2, 0, 0, 0, 0, jst)
+	want := map[time.Time][]time.Time{
+		may1: times[:2],
+		may2: times[2:],
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,36 @@
+package slicepat
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// HashSlice は要素の並びを含めた FNV-1a (64bit) ハッシュを返す。
+// 要素は %#v の文字列表現でハッシュするため、同じ内容のスライスは同じ値になる。
+// スナップショット間で変更があったかを安く判定し、完全な差分計算を省くために使う。
+// ポインタ要素はアドレスがハッシュされる点に注意。
+//
+// ハッシュは == ではなく %#v で書式化した値に従う。0 と -0 のように == では等しくても
+// 表記が異なる値は別のハッシュになり、逆に == では等しくない NaN 同士は同じハッシュになる。
+// 浮動小数点を含むスライスを重複判定やキャッシュのキーに使うなら、先に値を正規化しておくこと。
+func HashSlice[T comparable](src []T) uint64 {
+	h := fnv.New64a()
+	for _, v := range src {
+		fmt.Fprintf(h, "%#v", v)
+		h.Write([]byte{0}) // 要素の区切り
+	}
+	return h.Sum64()
+}
+
+// HashSliceUnordered は要素の順序に依存しないハッシュを返す（要素ごとのハッシュの XOR）。
+// XOR なので同じ要素が偶数個あると打ち消し合う。重複を含むスライス同士の比較には向かない。
+// 要素は HashSlice と同じく %#v の表記でハッシュするので、0 と -0 は別の要素として扱われる。
+func HashSliceUnordered[T comparable](src []T) uint64 {
+	var sum uint64
+	for _, v := range src {
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%#v", v)
+		sum ^= h.Sum64()
+	}
+	return sum
+}
@@ -0,0 +1,61 @@
+package slicepat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHashSlice(t *testing.T) {
+	a := genUsers(50)
+	b := append([]user(nil), a...)
+	if HashSlice(a) != HashSlice(b) {
+		t.Error("equal slices hash differently")
+	}
+
+	b[10].Name = "changed"
+	if HashSlice(a) == HashSlice(b) {
+		t.Error("modified slice hashes equally")
+	}
+
+	// 順序が変わると別のハッシュ
+	c := append([]user(nil), a...)
+	c[0], c[1] = c[1], c[0]
+	if HashSlice(a) == HashSlice(c) {
+		t.Error("reordered slice hashes equally with ordered variant")
+	}
+
+	// 要素の境界がずれても衝突しない
+	if HashSlice([]string{"ab", "c"}) == HashSlice([]string{"a", "bc"}) {
+		t.Error("element boundaries are not part of the hash")
+	}
+}
+
+func TestHashSliceUnordered(t *testing.T) {
+	a := []string{"Sendai", "Tokyo", "Osaka"}
+	b := []string{"Osaka", "Sendai", "Tokyo"}
+	if HashSliceUnordered(a) != HashSliceUnordered(b) {
+		t.Error("reordered slices hash differently with unordered variant")
+	}
+	if HashSliceUnordered(a) == HashSliceUnordered([]string{"Sendai", "Tokyo", "Nagoya"}) {
+		t.Error("different sets hash equally")
+	}
+}
+
+// ハッシュは == ではなく %#v の表記に従う
+func TestHashSlice_FollowsFormattedValue(t *testing.T) {
+	zero, negZero := []float64{0}, []float64{math.Copysign(0, -1)}
+	if zero[0] != negZero[0] {
+		t.Fatal("0 and -0 should be == equal")
+	}
+	if HashSlice(zero) == HashSlice(negZero) {
+		t.Error("HashSlice: 0 and -0 should hash differently")
+	}
+	if HashSliceUnordered(zero) == HashSliceUnordered(negZero) {
+		t.Error("HashSliceUnordered: 0 and -0 should hash differently")
+	}
+
+	nan := []float64{math.NaN()}
+	if HashSlice(nan) != HashSlice([]float64{math.NaN()}) {
+		t.Error("NaN slices should hash the same")
+	}
+}
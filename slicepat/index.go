@@ -0,0 +1,88 @@
+package slicepat
+
+// RetainIndexes は indexes に挙げた位置の要素だけを、indexes の並び順で集めた新しいスライスを返す。
+// 並び順は indexes に従うので、選択と同時に並べ替えにも使える。同じインデックスを複数回
+// 指定すればその要素も複数回入る。範囲外のインデックスは黙って読み飛ばす。
+func RetainIndexes[T any](src []T, indexes []int) []T {
+	out := make([]T, 0, len(indexes))
+	for _, i := range indexes {
+		if i < 0 || i >= len(src) {
+			continue
+		}
+		out = append(out, src[i])
+	}
+	return out
+}
+
+// SwapRemove は src[i] を末尾の要素で上書きしてから1つ短くしたスライスを返す（O(1)）。
+// 順序は保たれない。src の配列をそのまま使い、空いた末尾のスロットはゼロ値で埋める。
+// 順序が不要なホットループでの削除に使う。i が範囲外なら panic する。
+func SwapRemove[T any](src []T, i int) []T {
+	last := len(src) - 1
+	src[i] = src[last]
+	var zero T
+	src[last] = zero
+	return src[:last]
+}
+
+// ExcludeIndexes は indexes に挙げた位置の要素を除いた新しいスライスを返す。残りの順序は保つ。
+// RetainIndexes の補集合で、削除したい位置を先に計算してから使う。範囲外や重複したインデックスは無視する。
+func ExcludeIndexes[T any](src []T, indexes []int) []T {
+	drop := make(map[int]struct{}, len(indexes))
+	for _, i := range indexes {
+		drop[i] = struct{}{}
+	}
+	out := make([]T, 0, len(src))
+	for i, v := range src {
+		if _, ok := drop[i]; !ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// First は先頭の要素を返す。空スライスなら ok は false。
+func First[T any](src []T) (T, bool) {
+	if len(src) == 0 {
+		var zero T
+		return zero, false
+	}
+	return src[0], true
+}
+
+// Last は末尾の要素を返す。空スライスなら ok は false。
+func Last[T any](src []T) (T, bool) {
+	if len(src) == 0 {
+		var zero T
+		return zero, false
+	}
+	return src[len(src)-1], true
+}
+
+// FirstPtr は先頭のポインタを返す。空スライスか先頭が nil なら ok は false。
+func FirstPtr[T any](src []*T) (*T, bool) {
+	p, ok := First(src)
+	return p, ok && p != nil
+}
+
+// LastPtr は末尾のポインタを返す。空スライスか末尾が nil なら ok は false。
+func LastPtr[T any](src []*T) (*T, bool) {
+	p, ok := Last(src)
+	return p, ok && p != nil
+}
+
+// IndexFunc は pred が true を返す最初の要素の位置を返す。見つからなければ -1。
+// pred は先頭から順に呼ばれ、最初に一致した時点で打ち切る。
+func IndexFunc[T any](s []T, pred func(T) bool) int {
+	for i, v := range s {
+		if pred(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ContainsFunc は pred が true を返す要素があるかを返す。IndexFunc と同じく最初の一致で打ち切る。
+func ContainsFunc[T any](s []T, pred func(T) bool) bool {
+	return IndexFunc(s, pred) >= 0
+}
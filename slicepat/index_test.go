@@ -0,0 +1,127 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func userNames(us []user) []string {
+	out := make([]string, len(us))
+	for i, u := range us {
+		out[i] = u.Name
+	}
+	return out
+}
+
+func TestRetainIndexes(t *testing.T) {
+	got := RetainIndexes(demoUsers, []int{4, 0, -1, 2, 100})
+	if want := []string{"Eve", "Alice", "Carol"}; !reflect.DeepEqual(userNames(got), want) {
+		t.Errorf("got %v, want %v", userNames(got), want)
+	}
+
+	if got := RetainIndexes(demoUsers, nil); got == nil || len(got) != 0 {
+		t.Errorf("no indexes: got %v, want empty", got)
+	}
+}
+
+func TestSwapRemove(t *testing.T) {
+	src := []string{"a", "b", "c", "d"}
+	got := SwapRemove(src, 1)
+	if want := []string{"a", "d", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if src[3] != "" {
+		t.Errorf("vacated slot = %q, want zero value", src[3])
+	}
+
+	// 末尾の削除
+	got = SwapRemove(got, 2)
+	if want := []string{"a", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestExcludeIndexes(t *testing.T) {
+	got := ExcludeIndexes(demoUsers, []int{5, 0, 3, 3, -1, 42})
+	if want := []string{"Bob", "Carol", "Eve", "Grace"}; !reflect.DeepEqual(userNames(got), want) {
+		t.Errorf("got %v, want %v", userNames(got), want)
+	}
+	if got := ExcludeIndexes(demoUsers, nil); len(got) != len(demoUsers) {
+		t.Errorf("no indexes: len = %d, want %d", len(got), len(demoUsers))
+	}
+}
+
+func TestFirstLast(t *testing.T) {
+	if _, ok := First([]int(nil)); ok {
+		t.Error("First(empty) ok = true")
+	}
+	if _, ok := Last([]int{}); ok {
+		t.Error("Last(empty) ok = true")
+	}
+	if v, ok := First([]int{7}); !ok || v != 7 {
+		t.Errorf("First([7]) = %d, %v", v, ok)
+	}
+	if v, ok := Last([]int{7}); !ok || v != 7 {
+		t.Errorf("Last([7]) = %d, %v", v, ok)
+	}
+	if v, ok := Last([]int{1, 2, 3}); !ok || v != 3 {
+		t.Errorf("Last([1 2 3]) = %d, %v", v, ok)
+	}
+}
+
+func TestFirstLastPtr(t *testing.T) {
+	alice := &user{Name: "Alice"}
+	if _, ok := FirstPtr([]*user{}); ok {
+		t.Error("FirstPtr(empty) ok = true")
+	}
+	if p, ok := FirstPtr([]*user{alice}); !ok || p != alice {
+		t.Errorf("FirstPtr([alice]) = %v, %v", p, ok)
+	}
+	if p, ok := LastPtr([]*user{alice}); !ok || p != alice {
+		t.Errorf("LastPtr([alice]) = %v, %v", p, ok)
+	}
+	if _, ok := FirstPtr([]*user{nil, alice}); ok {
+		t.Error("FirstPtr with nil head: ok = true")
+	}
+	if _, ok := LastPtr([]*user{alice, nil}); ok {
+		t.Error("LastPtr with nil tail: ok = true")
+	}
+}
+
+func TestIndexFunc(t *testing.T) {
+	calls := 0
+	inTokyo := func(u user) bool { calls++; return u.City == "Tokyo" }
+
+	if got := IndexFunc(demoUsers, inTokyo); got != 2 {
+		t.Errorf("IndexFunc = %d, want 2", got)
+	}
+	if calls != 3 {
+		t.Errorf("pred called %d times, want 3 (short-circuit at first match)", calls)
+	}
+
+	calls = 0
+	if got := IndexFunc(demoUsers, func(u user) bool { calls++; return u.City == "Nagoya" }); got != -1 {
+		t.Errorf("no match: got %d, want -1", got)
+	}
+	if calls != len(demoUsers) {
+		t.Errorf("pred called %d times, want %d", calls, len(demoUsers))
+	}
+	if got := IndexFunc([]user(nil), inTokyo); got != -1 {
+		t.Errorf("empty: got %d, want -1", got)
+	}
+}
+
+func TestContainsFunc(t *testing.T) {
+	var visited []string
+	isBob := func(u user) bool { visited = append(visited, u.Name); return u.Name == "Bob" }
+
+	if !ContainsFunc(demoUsers, isBob) {
+		t.Error("ContainsFunc = false, want true")
+	}
+	if want := []string{"Alice", "Bob"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("visited %v, want %v in order", visited, want)
+	}
+	if ContainsFunc([]user{}, isBob) {
+		t.Error("empty: got true, want false")
+	}
+}
@@ -0,0 +1,26 @@
+package slicepat
+
+import "iter"
+
+// RoundRobin は slices の各スライスから1要素ずつ順番に取り出すイテレータを返す。
+// 使い切ったスライスは飛ばし、すべて使い切ったら終わる。
+// 都市ごとのユーザー群に公平に仕事を割り振るような用途向け。
+func RoundRobin[T any](slices [][]T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; ; i++ {
+			found := false
+			for _, s := range slices {
+				if i >= len(s) {
+					continue
+				}
+				found = true
+				if !yield(s[i]) {
+					return
+				}
+			}
+			if !found {
+				return
+			}
+		}
+	}
+}
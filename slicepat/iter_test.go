@@ -0,0 +1,39 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoundRobin(t *testing.T) {
+	groups := [][]string{
+		{"s1", "s2", "s3", "s4"},
+		{},
+		{"t1"},
+		{"k1", "k2"},
+	}
+	var got []string
+	for v := range RoundRobin(groups) {
+		got = append(got, v)
+	}
+	want := []string{"s1", "t1", "k1", "s2", "k2", "s3", "s4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// 途中で break できる
+	got = got[:0]
+	for v := range RoundRobin(groups) {
+		if len(got) == 2 {
+			break
+		}
+		got = append(got, v)
+	}
+	if want := []string{"s1", "t1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("early break: got %v, want %v", got, want)
+	}
+
+	for range RoundRobin[int](nil) {
+		t.Error("nil input should yield nothing")
+	}
+}
@@ -0,0 +1,186 @@
+package slicepat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// NilPolicy は JSON 出力時に nil 要素をどう扱うかを表す。
+type NilPolicy int
+
+const (
+	// NilSkip は nil 要素を出力しない。
+	NilSkip NilPolicy = iota
+	// NilAsNull は nil 要素を null として出力する（json.Marshal と同じ挙動）。
+	NilAsNull
+)
+
+// JSONSafeSlice は JSON 出力時に nil 要素を取り除く要素ポインタのスライス。
+// レスポンス構造体のフィールドをこの型にしておけば、nil が混入しても配列に null が現れない。
+// スライス自体が nil でも [] を出力する。読み込みは []*T と同じ。
+type JSONSafeSlice[T any] []*T
+
+// MarshalJSON は CompactNonNil で nil 要素を除いてから配列を出力する。
+func (s JSONSafeSlice[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(CompactNonNil([]*T(s)))
+}
+
+// StreamJSONArray は src を JSON 配列として w に書き出す。要素を1つずつエンコードして書くので、
+// 巨大なポインタスライスでも全体をバッファに溜めない。nil 要素は policy に従って扱う。
+// 空（または全要素が除外された）場合は [] を書く。
+func StreamJSONArray[T any](w io.Writer, src []*T, policy NilPolicy) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	first := true
+	for _, p := range src {
+		if p == nil && policy == NilSkip {
+			continue
+		}
+		b, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+// MarshalProjected は src を JSON 配列にする。各要素は fields に挙げた構造体フィールドだけを
+// fields の順に持つオブジェクトになるので、一覧 API で ID と Name だけ返すといった用途でペイロードを減らせる。
+// fields は Go のフィールド名で指定し、出力のキーには json タグの名前があればそれを使う。
+// T は構造体か構造体へのポインタで、nil ポインタの要素は null になる。
+// 存在しない（または非公開の）フィールド名を指定するとエラーを返す。
+func MarshalProjected[T any](src []T, fields []string) ([]byte, error) {
+	typ := reflect.TypeFor[T]()
+	isPtr := typ.Kind() == reflect.Pointer
+	if isPtr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("slicepat: MarshalProjected: %v is not a struct", typ)
+	}
+
+	type projected struct {
+		index []int
+		key   []byte
+	}
+	proj := make([]projected, len(fields))
+	for i, name := range fields {
+		f, ok := typ.FieldByName(name)
+		if !ok || !f.IsExported() {
+			return nil, fmt.Errorf("slicepat: MarshalProjected: unknown field %q in %v", name, typ)
+		}
+		key := f.Name
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag != "" && tag != "-" {
+			key = tag
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		proj[i] = projected{index: f.Index, key: keyJSON}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, v := range src {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		rv := reflect.ValueOf(&v).Elem()
+		if isPtr {
+			if rv.IsNil() {
+				buf.WriteString("null")
+				continue
+			}
+			rv = rv.Elem()
+		}
+		buf.WriteByte('{')
+		for j, p := range proj {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			b, err := json.Marshal(rv.FieldByIndex(p.index).Interface())
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(p.key)
+			buf.WriteByte(':')
+			buf.Write(b)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// JSONArrayReader は src を JSON 配列としてエンコードした内容を返す io.Reader を作る。
+// エンコードは Read されるたびに要素単位で行うので、全体をバッファせずに HTTP リクエストの
+// ボディへそのまま渡せる。src が nil でも null ではなく [] を出力する。
+// 要素のエンコードに失敗した場合は、その Read 以降のすべての Read がそのエラーを返す。
+func JSONArrayReader[T any](src []T) io.Reader {
+	return &jsonArrayReader[T]{src: src}
+}
+
+type jsonArrayReader[T any] struct {
+	src     []T
+	next    int
+	started bool
+	done    bool
+	err     error // エンコードに失敗したら保持し、以降の Read でも返し続ける
+	buf     bytes.Buffer
+}
+
+func (r *jsonArrayReader[T]) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.fill(); err != nil {
+			// io.EOF を返すと途中で切れた配列が完全に見えてしまうので、失敗を保持する
+			r.err = err
+			return 0, err
+		}
+	}
+	return r.buf.Read(p)
+}
+
+// fill は次の断片（開き括弧・要素1つ・閉じ括弧のいずれか）を buf に書く。
+func (r *jsonArrayReader[T]) fill() error {
+	switch {
+	case !r.started:
+		r.started = true
+		r.buf.WriteByte('[')
+	case r.next < len(r.src):
+		b, err := json.Marshal(r.src[r.next])
+		if err != nil {
+			return err
+		}
+		if r.next > 0 {
+			r.buf.WriteByte(',')
+		}
+		r.buf.Write(b)
+		r.next++
+	default:
+		r.done = true
+		r.buf.WriteByte(']')
+	}
+	return nil
+}
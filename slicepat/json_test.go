@@ -0,0 +1,173 @@
+package slicepat
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+type jsonUser struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestJSONSafeSlice(t *testing.T) {
+	type resp struct {
+		Users JSONSafeSlice[jsonUser] `json:"users"`
+	}
+	alice, bob := &jsonUser{ID: 1, Name: "Alice"}, &jsonUser{ID: 2, Name: "Bob"}
+	got, err := json.Marshal(resp{Users: JSONSafeSlice[jsonUser]{nil, alice, nil, nil, bob, nil}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(got, []byte("null")) {
+		t.Errorf("output contains null: %s", got)
+	}
+	var decoded struct{ Users []jsonUser }
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Users) != 2 {
+		t.Errorf("got %d users, want 2: %s", len(decoded.Users), got)
+	}
+
+	for _, s := range []JSONSafeSlice[jsonUser]{nil, {nil, nil}} {
+		got, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "[]" {
+			t.Errorf("got %s, want []", got)
+		}
+	}
+}
+
+func TestStreamJSONArray(t *testing.T) {
+	src := []*jsonUser{nil, {ID: 1, Name: "Alice"}, nil, {ID: 2, Name: "Bob"}, nil}
+	tests := []struct {
+		name   string
+		src    []*jsonUser
+		policy NilPolicy
+		want   string
+	}{
+		{"skip", src, NilSkip, `[{"id":1,"name":"Alice"},{"id":2,"name":"Bob"}]`},
+		{"null", src, NilAsNull, `[null,{"id":1,"name":"Alice"},null,{"id":2,"name":"Bob"},null]`},
+		{"all nil skip", []*jsonUser{nil, nil}, NilSkip, `[]`},
+		{"empty", nil, NilAsNull, `[]`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := StreamJSONArray(&buf, tt.src, tt.policy); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// NilAsNull は json.Marshal と同じ結果になる
+	var buf bytes.Buffer
+	if err := StreamJSONArray(&buf, src, NilAsNull); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(src)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("got %s, want %s", buf.Bytes(), want)
+	}
+}
+
+func TestMarshalProjected(t *testing.T) {
+	src := demoUsers[:2]
+	got, err := MarshalProjected(src, []string{"ID", "Name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"ID":1,"Name":"Alice"},{"ID":2,"Name":"Bob"}]`; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	for _, omitted := range []string{"Age", "Email", "City"} {
+		if bytes.Contains(got, []byte(omitted)) {
+			t.Errorf("output %s contains omitted field %s", got, omitted)
+		}
+	}
+
+	// ポインタ要素と json タグ
+	ptrs := []*jsonUser{{ID: 1, Name: "Alice"}, nil}
+	got, err = MarshalProjected(ptrs, []string{"Name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"name":"Alice"},null]`; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if _, err := MarshalProjected(src, []string{"ID", "Password"}); err == nil {
+		t.Error("expected error for unknown field")
+	}
+	if _, err := MarshalProjected([]int{1}, []string{"ID"}); err == nil {
+		t.Error("expected error for non-struct element")
+	}
+}
+
+func TestJSONArrayReader(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 500} {
+		src := genUsers(n)
+		got, err := io.ReadAll(JSONArrayReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := json.Marshal(src)
+		if !bytes.Equal(got, want) {
+			t.Errorf("n=%d: got %s, want %s", n, got, want)
+		}
+	}
+
+	// 小さなバッファで少しずつ読んでも同じ結果
+	src := ToPtrSlice(demoUsers)
+	src[2] = nil
+	r := JSONArrayReader(src)
+	var out bytes.Buffer
+	p := make([]byte, 7)
+	for {
+		n, err := r.Read(p)
+		out.Write(p[:n])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, _ := json.Marshal(src)
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("got %s, want %s", out.Bytes(), want)
+	}
+
+	if got, _ := io.ReadAll(JSONArrayReader([]int(nil))); string(got) != "[]" {
+		t.Errorf("nil input: got %s, want []", got)
+	}
+}
+
+func TestJSONArrayReader_Error(t *testing.T) {
+	_, err := io.ReadAll(JSONArrayReader([]any{1, make(chan int)}))
+	if err == nil {
+		t.Error("expected encoding error")
+	}
+
+	// 失敗後にもう一度 Read しても io.EOF ではなく同じエラーが返る
+	r := JSONArrayReader([]any{1, make(chan int)})
+	buf := make([]byte, 64)
+	var first error
+	for first == nil {
+		_, first = r.Read(buf)
+	}
+	if first == io.EOF {
+		t.Fatal("got io.EOF, want encoding error")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Read(buf); err != first {
+			t.Errorf("read %d after failure: got %v, want %v", i, err, first)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package slicepat
+
+import (
+	"cmp"
+	"sort"
+)
+
+// TransformMap は m をキーの昇順に走査して f を適用した結果をスライスで返す。
+// map の走査順はランダムなので、グループ化したデータから再現性のある出力を作るときに使う。
+func TransformMap[K cmp.Ordered, V, W any](m map[K]V, f func(K, V) W) []W {
+	keys := SortedKeys(m)
+	out := make([]W, len(keys))
+	for i, k := range keys {
+		out[i] = f(k, m[k])
+	}
+	return out
+}
+
+// FilterKeys は pred が true を返したエントリだけを持つ新しい map を返す。m は変更しない。
+// 値がポインタの場合、結果の map は m と同じポインタを共有する。
+func FilterKeys[K comparable, V any](m map[K]V, pred func(K, V) bool) map[K]V {
+	out := make(map[K]V)
+	for k, v := range m {
+		if pred(k, v) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// SortedKeys は m のキーを昇順に並べたスライスを返す。決まった順で map を走査するための部品。
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return SortedKeysFunc(m, func(a, b K) bool { return a < b })
+}
+
+// SortedKeysFunc は m のキーを less の順に並べたスライスを返す。
+// cmp.Ordered でないキー（構造体など）に使う。
+func SortedKeysFunc[K comparable, V any](m map[K]V, less func(a, b K) bool) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+	return keys
+}
+
+// IndexMap は各要素のキーから src 内の位置への map を返す。ID による繰り返しの検索を O(1) にする。
+// キーが重複している場合は最初の位置を残す。
+func IndexMap[K comparable, T any](src []T, key func(T) K) map[K]int {
+	out := make(map[K]int, len(src))
+	for i, v := range src {
+		k := key(v)
+		if _, ok := out[k]; !ok {
+			out[k] = i
+		}
+	}
+	return out
+}
+
+// ToSafeMap は各要素のコピーへのポインタをキーで引ける map を返す。
+// &src[i] を格納すると map 経由の変更が src の配列に漏れるが、この関数はコピーを指すので
+// map の値を変更しても src には影響しない。キーが重複している場合は最初の要素を残す。
+func ToSafeMap[K comparable, T any](src []T, key func(T) K) map[K]*T {
+	out := make(map[K]*T, len(src))
+	for _, v := range src {
+		k := key(v)
+		if _, ok := out[k]; ok {
+			continue
+		}
+		cp := v
+		out[k] = &cp
+	}
+	return out
+}
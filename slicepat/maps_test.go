@@ -0,0 +1,113 @@
+package slicepat
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestTransformMap(t *testing.T) {
+	byCity := map[string][]user{
+		"Tokyo":    {{ID: 1}, {ID: 2}},
+		"Sendai":   {{ID: 3}},
+		"Kanazawa": {{ID: 4}, {ID: 5}, {ID: 6}},
+	}
+	summary := func(city string, us []user) string { return city + ":" + strconv.Itoa(len(us)) }
+
+	want := []string{"Kanazawa:3", "Sendai:1", "Tokyo:2"}
+	for i := 0; i < 20; i++ {
+		if got := TransformMap(byCity, summary); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFilterKeys(t *testing.T) {
+	byID := map[uint]*user{}
+	for _, u := range genPtrUsers(10) {
+		byID[u.ID] = u
+	}
+	got := FilterKeys(byID, func(id uint, _ *user) bool { return id >= 3 && id <= 5 })
+
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3", len(got))
+	}
+	for id := uint(3); id <= 5; id++ {
+		if got[id] != byID[id] {
+			t.Errorf("got[%d] = %v, want %v", id, got[id], byID[id])
+		}
+	}
+	if len(byID) != 10 {
+		t.Errorf("original map modified: len = %d", len(byID))
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	m := map[string]int{"Tokyo": 1, "Kanazawa": 2, "Sendai": 3, "Osaka": 4}
+	if got, want := SortedKeys(m), []string{"Kanazawa", "Osaka", "Sendai", "Tokyo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := SortedKeys(map[int]bool{}); got == nil || len(got) != 0 {
+		t.Errorf("empty map: got %v, want empty", got)
+	}
+}
+
+func TestSortedKeysFunc(t *testing.T) {
+	type cityAge struct {
+		City string
+		Age  uint
+	}
+	m := map[cityAge]int{
+		{"Tokyo", 30}:  1,
+		{"Sendai", 40}: 2,
+		{"Tokyo", 20}:  3,
+	}
+	got := SortedKeysFunc(m, func(a, b cityAge) bool {
+		if a.City != b.City {
+			return a.City < b.City
+		}
+		return a.Age < b.Age
+	})
+	want := []cityAge{{"Sendai", 40}, {"Tokyo", 20}, {"Tokyo", 30}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestIndexMap(t *testing.T) {
+	byID := IndexMap(demoUsers, func(u user) uint { return u.ID })
+	if len(byID) != len(demoUsers) {
+		t.Fatalf("len = %d, want %d", len(byID), len(demoUsers))
+	}
+	for i, u := range demoUsers {
+		if byID[u.ID] != i {
+			t.Errorf("byID[%d] = %d, want %d", u.ID, byID[u.ID], i)
+		}
+	}
+
+	// 重複キーは最初の位置
+	byCity := IndexMap(demoUsers, userCity)
+	want := map[string]int{"Sendai": 0, "Kanazawa": 1, "Tokyo": 2, "Osaka": 3}
+	if !reflect.DeepEqual(byCity, want) {
+		t.Errorf("got %v, want %v", byCity, want)
+	}
+}
+
+func TestToSafeMap(t *testing.T) {
+	src := genUsers(5)
+	byID := ToSafeMap(src, func(u user) uint { return u.ID })
+	if len(byID) != len(src) {
+		t.Fatalf("len = %d, want %d", len(byID), len(src))
+	}
+	if byID[3].Name != src[2].Name {
+		t.Errorf("byID[3].Name = %q, want %q", byID[3].Name, src[2].Name)
+	}
+
+	byID[3].Name = "changed"
+	if src[2].Name == "changed" {
+		t.Error("mutating a map value changed the source slice")
+	}
+	if byID[3] == &src[2] {
+		t.Error("map value aliases the source element")
+	}
+}
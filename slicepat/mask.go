@@ -0,0 +1,27 @@
+package slicepat
+
+// Compress は mask[i] が true の位置にある src の要素だけを順に集めて返す。
+// 選別の判定を別の処理（並列の述語評価など）で済ませてある場合に使う。
+// len(src) と len(mask) が異なる場合は panic する。
+func Compress[T any](src []T, mask []bool) []T {
+	if len(src) != len(mask) {
+		panic("slicepat: Compress: len(src) != len(mask)")
+	}
+	out := make([]T, 0, len(src))
+	for i, v := range src {
+		if mask[i] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Mask は src の各要素に対する pred の結果を並べたスライスを返す。
+// 一度計算したマスクを Compress や件数の集計に使い回せる。
+func Mask[T any](src []T, pred func(T) bool) []bool {
+	out := make([]bool, len(src))
+	for i, v := range src {
+		out[i] = pred(v)
+	}
+	return out
+}
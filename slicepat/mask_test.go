@@ -0,0 +1,52 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	src := []string{"a", "b", "c", "d", "e"}
+	mask := []bool{true, false, false, true, true}
+	if got, want := Compress(src, mask), []string{"a", "d", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCompress_LengthMismatchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on length mismatch")
+		}
+	}()
+	Compress([]int{1, 2, 3}, []bool{true})
+}
+
+func TestMask_MatchesFilter(t *testing.T) {
+	src := genUsers(100)
+	young := func(u user) bool { return u.Age < 30 }
+
+	var filtered []user
+	for _, u := range src {
+		if young(u) {
+			filtered = append(filtered, u)
+		}
+	}
+
+	mask := Mask(src, young)
+	if len(mask) != len(src) {
+		t.Fatalf("len(mask) = %d, want %d", len(mask), len(src))
+	}
+	count := 0
+	for _, m := range mask {
+		if m {
+			count++
+		}
+	}
+	if count != len(filtered) {
+		t.Errorf("mask count = %d, want %d", count, len(filtered))
+	}
+	if got := Compress(src, mask); !reflect.DeepEqual(got, filtered) {
+		t.Error("Compress(src, Mask(src, pred)) differs from filtering with pred")
+	}
+}
@@ -0,0 +1,58 @@
+package slicepat
+
+import "encoding/json"
+
+// OptionalSlice は「未設定」「明示的に空」「要素あり」の3状態を持つ JSON 用のスライス。
+// パターンB（*[]User）と同じく、*OptionalSlice[T] のフィールドに omitempty を付けて使う。
+//
+//	type Resp struct {
+//		Users *slicepat.OptionalSlice[User] `json:"users,omitempty"`
+//	}
+//
+// nil ポインタならキーを省略し、空なら（中身が nil スライスでも）[] を、それ以外は配列を出力する。
+// 読み込みではキーがなければ nil ポインタのまま、[] なら空スライスを指すポインタになる。
+type OptionalSlice[T any] []T
+
+// NewOptionalSlice は vs を要素に持つ設定済みの OptionalSlice を返す。
+// 引数なしで呼べば「明示的に空」の状態になる。vs はコピーしない。
+func NewOptionalSlice[T any](vs ...T) *OptionalSlice[T] {
+	if vs == nil {
+		vs = []T{}
+	}
+	s := OptionalSlice[T](vs)
+	return &s
+}
+
+// IsSet は値が設定されているか（nil ポインタでないか）を返す。nil レシーバでも呼べる。
+func (s *OptionalSlice[T]) IsSet() bool {
+	return s != nil
+}
+
+// Values は要素を []T として返す。未設定なら nil を返す。
+func (s *OptionalSlice[T]) Values() []T {
+	if s == nil {
+		return nil
+	}
+	return []T(*s)
+}
+
+// MarshalJSON は要素がなければ null ではなく [] を出力する。
+func (s OptionalSlice[T]) MarshalJSON() ([]byte, error) {
+	if len(s) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]T(s))
+}
+
+// UnmarshalJSON は [] を nil ではない空スライスとして読み込み、「明示的に空」を保つ。
+func (s *OptionalSlice[T]) UnmarshalJSON(data []byte) error {
+	var vs []T
+	if err := json.Unmarshal(data, &vs); err != nil {
+		return err
+	}
+	if vs == nil {
+		vs = []T{}
+	}
+	*s = vs
+	return nil
+}
@@ -0,0 +1,75 @@
+package slicepat
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type optionalResp struct {
+	Users *OptionalSlice[jsonUser] `json:"users,omitempty"`
+}
+
+func TestOptionalSlice_Marshal(t *testing.T) {
+	tests := []struct {
+		name string
+		in   optionalResp
+		want string
+	}{
+		{"unset", optionalResp{}, `{}`},
+		{"empty", optionalResp{Users: NewOptionalSlice[jsonUser]()}, `{"users":[]}`},
+		{"nil inside", optionalResp{Users: new(OptionalSlice[jsonUser])}, `{"users":[]}`},
+		{"values", optionalResp{Users: NewOptionalSlice(jsonUser{ID: 1, Name: "Alice"})}, `{"users":[{"id":1,"name":"Alice"}]}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.in)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOptionalSlice_Unmarshal(t *testing.T) {
+	var unset optionalResp
+	if err := json.Unmarshal([]byte(`{}`), &unset); err != nil {
+		t.Fatal(err)
+	}
+	if unset.Users.IsSet() || unset.Users.Values() != nil {
+		t.Errorf("absent: got %v, want unset", unset.Users)
+	}
+
+	var empty optionalResp
+	if err := json.Unmarshal([]byte(`{"users":[]}`), &empty); err != nil {
+		t.Fatal(err)
+	}
+	if !empty.Users.IsSet() || empty.Users.Values() == nil || len(empty.Users.Values()) != 0 {
+		t.Errorf("empty: got %#v, want set and empty", empty.Users)
+	}
+
+	var full optionalResp
+	if err := json.Unmarshal([]byte(`{"users":[{"id":1,"name":"Alice"},{"id":2,"name":"Bob"}]}`), &full); err != nil {
+		t.Fatal(err)
+	}
+	want := []jsonUser{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}}
+	if !full.Users.IsSet() || !reflect.DeepEqual(full.Users.Values(), want) {
+		t.Errorf("values: got %v, want %v", full.Users.Values(), want)
+	}
+
+	// 3状態とも書き出して読み戻すと元の JSON に戻る
+	for _, s := range []string{`{}`, `{"users":[]}`, `{"users":[{"id":1,"name":"Alice"}]}`} {
+		var r optionalResp
+		if err := json.Unmarshal([]byte(s), &r); err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != s {
+			t.Errorf("round trip: got %s, want %s", got, s)
+		}
+	}
+}
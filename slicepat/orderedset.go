@@ -0,0 +1,51 @@
+package slicepat
+
+// OrderedSet は挿入順を保つ集合。重複を除きつつ最初に追加した順で取り出したい場合
+// （ユーザーIDの蓄積など）に使う。ゼロ値のまま使える。並行利用には対応しない。
+type OrderedSet[T comparable] struct {
+	index map[T]struct{}
+	items []T
+}
+
+// NewOrderedSet は vs を順に追加した OrderedSet を返す。
+func NewOrderedSet[T comparable](vs ...T) *OrderedSet[T] {
+	s := &OrderedSet[T]{}
+	s.AddAll(vs)
+	return s
+}
+
+// Add は v を末尾に追加する。すでに含まれていれば何もせず false を返す。
+func (s *OrderedSet[T]) Add(v T) bool {
+	if s.index == nil {
+		s.index = make(map[T]struct{})
+	}
+	if _, ok := s.index[v]; ok {
+		return false
+	}
+	s.index[v] = struct{}{}
+	s.items = append(s.items, v)
+	return true
+}
+
+// AddAll は vs を順に Add する。
+func (s *OrderedSet[T]) AddAll(vs []T) {
+	for _, v := range vs {
+		s.Add(v)
+	}
+}
+
+// Contains は v が含まれているかを返す。
+func (s *OrderedSet[T]) Contains(v T) bool {
+	_, ok := s.index[v]
+	return ok
+}
+
+// Len は要素数を返す。
+func (s *OrderedSet[T]) Len() int {
+	return len(s.items)
+}
+
+// Slice は要素を追加順に並べたスライスのコピーを返す。
+func (s *OrderedSet[T]) Slice() []T {
+	return append(make([]T, 0, len(s.items)), s.items...)
+}
@@ -0,0 +1,48 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderedSet(t *testing.T) {
+	var s OrderedSet[uint]
+	if !s.Add(5) || !s.Add(3) || s.Add(5) {
+		t.Error("Add should report whether the value was new")
+	}
+	s.AddAll([]uint{1, 3, 7, 5, 1, 9})
+	if got, want := s.Slice(), []uint{5, 3, 1, 7, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if s.Len() != 5 || !s.Contains(7) || s.Contains(2) {
+		t.Errorf("Len=%d Contains(7)=%v Contains(2)=%v", s.Len(), s.Contains(7), s.Contains(2))
+	}
+
+	// Slice はコピーを返す
+	out := s.Slice()
+	out[0] = 100
+	if s.Slice()[0] != 5 {
+		t.Error("mutating Slice() result changed the set")
+	}
+}
+
+func TestOrderedSet_ManyAdds(t *testing.T) {
+	s := NewOrderedSet[int]()
+	var want []int
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 100; i++ {
+			v := (i * 37) % 50 // 同じ値が何度も出る
+			if round == 0 && i < 50 {
+				want = append(want, v)
+			}
+			s.Add(v)
+		}
+	}
+	if got := s.Slice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("order not stable across adds:\ngot  %v\nwant %v", got, want)
+	}
+
+	if got := (&OrderedSet[int]{}).Slice(); got == nil || len(got) != 0 {
+		t.Errorf("empty set: got %v, want empty non-nil slice", got)
+	}
+}
@@ -0,0 +1,43 @@
+package slicepat
+
+// Partition は s を pred が true の要素（matched）と false の要素（rest）に1パスで分ける。
+// Filter を2回かけるのと同じ結果で、どちらも s での相対順を保つ。
+// 戻り値は s とメモリを共有しない新しいスライスで、該当なしでも nil ではなく空スライスになる。
+func Partition[T any](s []T, pred func(T) bool) (matched, rest []T) {
+	matched, rest = make([]T, 0), make([]T, 0)
+	for _, v := range s {
+		if pred(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return matched, rest
+}
+
+// PartitionIndexed は src を pred が true の要素（yes）と false の要素（no）に分け、
+// 結果の位置から元のインデックスへの対応 origIndex も返す。
+// どちらの結果も src での相対順を保つ（安定）。
+//
+// origIndex のキーは yes と no を連結した並びでの位置を表す。
+// yes[i] の元の位置は origIndex[i]、no[j] の元の位置は origIndex[len(yes)+j] になる。
+func PartitionIndexed[T any](src []T, pred func(T) bool) (yes, no []T, origIndex map[int]int) {
+	var yesIdx, noIdx []int
+	for i, v := range src {
+		if pred(v) {
+			yes = append(yes, v)
+			yesIdx = append(yesIdx, i)
+		} else {
+			no = append(no, v)
+			noIdx = append(noIdx, i)
+		}
+	}
+	origIndex = make(map[int]int, len(src))
+	for i, orig := range yesIdx {
+		origIndex[i] = orig
+	}
+	for j, orig := range noIdx {
+		origIndex[len(yes)+j] = orig
+	}
+	return yes, no, origIndex
+}
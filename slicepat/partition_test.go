@@ -0,0 +1,65 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPartition(t *testing.T) {
+	src := demoUsers
+	adult := func(u user) bool { return u.Age >= 30 }
+	matched, rest := Partition(src, adult)
+
+	if len(matched)+len(rest) != len(src) {
+		t.Fatalf("len(matched)=%d len(rest)=%d, want total %d", len(matched), len(rest), len(src))
+	}
+	if got, want := userNames(matched), []string{"Bob", "Carol", "Eve", "Frank"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("matched: got %v, want %v", got, want)
+	}
+	if got, want := userNames(rest), []string{"Alice", "Dave", "Grace"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rest: got %v, want %v", got, want)
+	}
+
+	// 結果は src と共有しない
+	matched[0].Name = "changed"
+	if src[1].Name != "Bob" {
+		t.Error("Partition result aliases src")
+	}
+
+	none, all := Partition(src, func(user) bool { return false })
+	if none == nil || len(none) != 0 || len(all) != len(src) {
+		t.Errorf("no match: got matched=%v len(rest)=%d", none, len(all))
+	}
+}
+
+func TestPartitionIndexed(t *testing.T) {
+	src := genUsers(20)
+	inCity3 := func(u user) bool { return u.City == "City3" }
+	yes, no, origIndex := PartitionIndexed(src, inCity3)
+
+	if len(yes)+len(no) != len(src) || len(origIndex) != len(src) {
+		t.Fatalf("len(yes)=%d len(no)=%d len(origIndex)=%d, want total %d",
+			len(yes), len(no), len(origIndex), len(src))
+	}
+
+	// 結果を元の位置へ書き戻すと src が復元できる
+	restored := make([]user, len(src))
+	for i, u := range yes {
+		restored[origIndex[i]] = u
+	}
+	for j, u := range no {
+		restored[origIndex[len(yes)+j]] = u
+	}
+	if !reflect.DeepEqual(restored, src) {
+		t.Error("round trip through origIndex did not restore src")
+	}
+
+	// 両パーティションとも相対順を保つ
+	for _, part := range [][]user{yes, no} {
+		for i := 1; i < len(part); i++ {
+			if part[i-1].ID >= part[i].ID {
+				t.Errorf("order not preserved: %d before %d", part[i-1].ID, part[i].ID)
+			}
+		}
+	}
+}
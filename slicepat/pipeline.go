@@ -0,0 +1,86 @@
+package slicepat
+
+type stageKind int
+
+const (
+	stageMap stageKind = iota
+	stageFilter
+	stageTake
+)
+
+type stage[T any] struct {
+	kind stageKind
+	f    func(T) T
+	pred func(T) bool
+	n    int
+}
+
+// Pipeline は Map / Filter / Take の各段を記録しておき、Run で1パスにまとめて実行する。
+// 段ごとに中間スライスを作らないため、即時評価で関数を連結するよりアロケーションが少ない。
+// 各段は登録順に適用される。
+type Pipeline[T any] struct {
+	stages []stage[T]
+}
+
+// NewPipeline は空の Pipeline を返す。
+func NewPipeline[T any]() *Pipeline[T] {
+	return &Pipeline[T]{}
+}
+
+// Map は要素を f で変換する段を追加する。
+func (p *Pipeline[T]) Map(f func(T) T) *Pipeline[T] {
+	p.stages = append(p.stages, stage[T]{kind: stageMap, f: f})
+	return p
+}
+
+// Filter は pred が true の要素だけを後段へ渡す段を追加する。
+func (p *Pipeline[T]) Filter(pred func(T) bool) *Pipeline[T] {
+	p.stages = append(p.stages, stage[T]{kind: stageFilter, pred: pred})
+	return p
+}
+
+// Take はこの段を通過できる要素を先頭から n 個までに制限する段を追加する。
+// 上限に達した時点で Run は残りの入力を走査せずに終わるので、前段の関数もそれ以降は呼ばれない。
+func (p *Pipeline[T]) Take(n int) *Pipeline[T] {
+	p.stages = append(p.stages, stage[T]{kind: stageTake, n: n})
+	return p
+}
+
+// Run は src に全段を1パスで適用した結果を返す。src は変更しない。
+// 結果が0件でも nil ではなく空スライスを返す。
+// Take の計数は Run ごとにリセットされるので、同じ Pipeline を何度でも使い回せる。
+func (p *Pipeline[T]) Run(src []T) []T {
+	out := make([]T, 0)
+	for _, s := range p.stages {
+		if s.kind == stageTake && s.n <= 0 {
+			// どの要素もこの段を通過できない
+			return out
+		}
+	}
+	taken := make([]int, len(p.stages))
+	for _, v := range src {
+		keep, last := true, false
+		for i, s := range p.stages {
+			switch s.kind {
+			case stageMap:
+				v = s.f(v)
+			case stageFilter:
+				keep = s.pred(v)
+			case stageTake:
+				taken[i]++
+				// この要素で上限に達したら、後段まで流したうえで走査を打ち切る
+				last = last || taken[i] == s.n
+			}
+			if !keep {
+				break
+			}
+		}
+		if keep {
+			out = append(out, v)
+		}
+		if last {
+			return out
+		}
+	}
+	return out
+}
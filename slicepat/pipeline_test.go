@@ -0,0 +1,93 @@
+package slicepat
+
+import (
+	"reflect"
+	"testing"
+)
+
+// 即時評価で同じ処理を組み立てたもの（比較用）
+func eagerMapFilterTake(src []user, f func(user) user, pred func(user) bool, n int) []user {
+	mapped := make([]user, len(src))
+	for i, u := range src {
+		mapped[i] = f(u)
+	}
+	var filtered []user
+	for _, u := range mapped {
+		if pred(u) {
+			filtered = append(filtered, u)
+		}
+	}
+	if len(filtered) > n {
+		filtered = filtered[:n]
+	}
+	return filtered
+}
+
+func TestPipeline_MatchesEager(t *testing.T) {
+	src := genUsers(1000)
+	older := func(u user) user { u.Age += 10; return u }
+	inCity5 := func(u user) bool { return u.City == "City5" }
+
+	for _, n := range []int{0, 1, 30, 100, 1000} {
+		got := NewPipeline[user]().Map(older).Filter(inCity5).Take(n).Run(src)
+		want := eagerMapFilterTake(src, older, inCity5, n)
+		if got == nil {
+			t.Fatalf("Take(%d): got nil, want non-nil slice", n)
+		}
+		if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("Take(%d): got %d elems, want %d", n, len(got), len(want))
+		}
+	}
+}
+
+func TestPipeline_StageOrder(t *testing.T) {
+	src := []int{1, 2, 3, 4, 5, 6}
+	double := func(v int) int { return v * 2 }
+	even := func(v int) bool { return v%2 == 0 }
+
+	// Take の後に Filter: 先頭3件だけを見てから偶数に絞る
+	got := NewPipeline[int]().Take(3).Filter(even).Map(double).Run(src)
+	if want := []int{4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// 同じ Pipeline を再実行しても Take の計数は持ち越さない
+	p := NewPipeline[int]().Filter(even).Take(2)
+	for i := 0; i < 2; i++ {
+		if got, want := p.Run(src), []int{2, 4}; !reflect.DeepEqual(got, want) {
+			t.Errorf("run %d: got %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestPipeline_DoesNotMutateSource(t *testing.T) {
+	src := genUsers(10)
+	orig := append([]user(nil), src...)
+	NewPipeline[user]().Map(func(u user) user { u.Name = "x"; return u }).Run(src)
+	if !reflect.DeepEqual(src, orig) {
+		t.Error("src was mutated")
+	}
+}
+
+func TestPipeline_TakeStopsScanning(t *testing.T) {
+	src := GenerateSlice(100000, func(i int) int { return i })
+	var mapCalls, filterCalls int
+	got := NewPipeline[int]().
+		Map(func(v int) int { mapCalls++; return v + 1 }).
+		Filter(func(v int) bool { filterCalls++; return v%2 == 0 }).
+		Take(2).
+		Run(src)
+	if want := []int{2, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// 2件目が通過した要素（src[3]）で打ち切り、以降は Map も Filter も呼ばない
+	if mapCalls != 4 || filterCalls != 4 {
+		t.Errorf("Map called %d times, Filter called %d times, want 4 each", mapCalls, filterCalls)
+	}
+
+	// Take(0) なら1件も走査しない
+	mapCalls = 0
+	if got := NewPipeline[int]().Map(func(v int) int { mapCalls++; return v }).Take(0).Run(src); len(got) != 0 || mapCalls != 0 {
+		t.Errorf("Take(0): got %v, Map called %d times", got, mapCalls)
+	}
+}
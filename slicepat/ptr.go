@@ -0,0 +1,163 @@
+package slicepat
+
+// Defragment は src の非 nil 要素を順序を保ったまま先頭に詰め、詰めた後のスライスと
+// 空いたスロット数 freed を返す。src の配列をそのまま再利用し、末尾の空いた領域は
+// nil で埋めて古いポインタを GC できるようにする。
+// 大きなポインタバッファを使い回す場合の、その場で行う nil 除去。
+func Defragment[T any](src []*T) (compacted []*T, freed int) {
+	n := 0
+	for _, p := range src {
+		if p != nil {
+			src[n] = p
+			n++
+		}
+	}
+	tail := src[n:]
+	for i := range tail {
+		tail[i] = nil
+	}
+	return src[:n], len(tail)
+}
+
+// DeepCopyShared は src の各ポインタが指す値を複製した新しいスライスを返す。
+// 同じポインタが複数回現れる場合は1度だけ複製し、その複製を使い回すので、
+// 元のスライスの共有関係（同一オブジェクトを指すスロット同士）がそのまま保たれる。
+// nil 要素は nil のまま残る。値の中のポインタやスライスまでは複製しない（1段の深さ）。
+func DeepCopyShared[T any](src []*T) []*T {
+	copies := make(map[*T]*T, len(src))
+	out := make([]*T, len(src))
+	for i, p := range src {
+		if p == nil {
+			continue
+		}
+		cp, ok := copies[p]
+		if !ok {
+			v := *p
+			cp = &v
+			copies[p] = cp
+		}
+		out[i] = cp
+	}
+	return out
+}
+
+// FillNil は src の nil 要素を factory(i) が作った値で置き換える（その場で変更する）。
+// 疎なポインタスライスを詰める代わりに、空きスロットを埋めて位置を保ちたいときに使う。
+func FillNil[T any](src []*T, factory func(i int) *T) {
+	for i, p := range src {
+		if p == nil {
+			src[i] = factory(i)
+		}
+	}
+}
+
+// CompactDeref は nil 要素を除き、残りを参照外しした値スライスを1パスで返す。
+// nil 除去と値スライス化を順に行うより中間スライスが1つ少ない。JSON 出力直前のホットパス向け。
+// すべて nil でも nil ではなく空スライスを返すので、JSON では [] になる。
+func CompactDeref[T any](src []*T) []T {
+	out := make([]T, 0, len(src))
+	for _, p := range src {
+		if p != nil {
+			out = append(out, *p)
+		}
+	}
+	return out
+}
+
+// ToPtrSlice は値スライスから要素ポインタのスライスを作る。
+// 各要素を新しい変数にコピーしてからアドレスを取るので、結果のポインタは vs の配列を指さない。
+// 結果経由で変更しても vs には影響しない。
+func ToPtrSlice[T any](vs []T) []*T {
+	out := make([]*T, len(vs))
+	for i := range vs {
+		v := vs[i]
+		out[i] = &v
+	}
+	return out
+}
+
+// ToValueSlice は要素ポインタのスライスを値スライスに変換する。nil 要素は読み飛ばす。
+// ToPtrSlice の逆方向で、中身は CompactDeref と同じ（すべて nil でも空スライスを返す）。
+func ToValueSlice[T any](ps []*T) []T {
+	return CompactDeref(ps)
+}
+
+// DeepCopyPtrSlice は各要素が指す値を複製した新しいポインタスライスを返す。
+// nil 要素は取り除かずに同じ位置に nil のまま残す。キャッシュを呼び出し側へ渡す前の防衛的コピーに使う。
+// 同じポインタが複数回現れてもそれぞれ別に複製する（共有関係を保ちたい場合は DeepCopyShared）。
+func DeepCopyPtrSlice[T any](ps []*T) []*T {
+	out := make([]*T, 0, len(ps))
+	for _, p := range ps {
+		if p == nil {
+			out = append(out, nil)
+			continue
+		}
+		cp := *p
+		out = append(out, &cp)
+	}
+	return out
+}
+
+// CompactNonNil は nil 要素を除いた新しいスライスを返す。順序は保ち、ポインタはそのまま共有する。
+// すべて nil でも nil ではなく空スライスを返すので、JSON に null が混ざらず [] になる。
+func CompactNonNil[T any](ps []*T) []*T {
+	return Filter(ps, func(p *T) bool { return p != nil })
+}
+
+// CompactNonNilInPlace は ps の配列を再利用して nil 要素を取り除き、短くなったスライスを返す。
+// 空いた末尾は nil で埋めるので、取り除かれた位置に古いポインタが残ってリークすることはない。
+// 空き数も知りたい場合は Defragment を使う。
+func CompactNonNilInPlace[T any](ps []*T) []*T {
+	out, _ := Defragment(ps)
+	return out
+}
+
+// DeepCopyInto は src の各要素を clone で dst の同じ位置へ複製する。dst[i] がすでに非 nil なら
+// その領域を再利用し、nil なら新しく確保する。コピーを繰り返すループでアロケーションを減らすためのもの。
+// clone(dst, src) は src の内容を dst に書き込む関数（単純な値なら *dst = *src でよい）。
+// src[i] が nil なら dst[i] も nil にする。len(dst) < len(src) なら panic する。
+func DeepCopyInto[T any](dst, src []*T, clone func(dst, src *T)) {
+	if len(dst) < len(src) {
+		panic("slicepat: DeepCopyInto: dst is shorter than src")
+	}
+	for i, p := range src {
+		if p == nil {
+			dst[i] = nil
+			continue
+		}
+		if dst[i] == nil {
+			dst[i] = new(T)
+		}
+		clone(dst[i], p)
+	}
+}
+
+// ValueSliceToPtrElems はパターンA（値スライス）をパターンC（要素ポインタのスライス）に変換する。
+// ToPtrSlice と同じで、結果のポインタは vs の配列を指さない。
+func ValueSliceToPtrElems[T any](vs []T) []*T {
+	return ToPtrSlice(vs)
+}
+
+// PtrElemsToValueSlice はパターンC をパターンA に変換する。
+// ToValueSlice と違い nil 要素は読み飛ばさずゼロ値にするので、長さと位置が ps と一致する。
+func PtrElemsToValueSlice[T any](ps []*T) []T {
+	out := make([]T, len(ps))
+	for i, p := range ps {
+		if p != nil {
+			out[i] = *p
+		}
+	}
+	return out
+}
+
+// ValueSliceToSlicePtr はパターンA をパターンB（スライスそのもののポインタ）に変換する。
+// 要素は新しい配列にコピーするので、結果経由の変更は vs に影響しない。
+// nil スライスは nil ポインタ（omitempty でキー省略）、空スライスは空配列へのポインタ（[]）になる。
+func ValueSliceToSlicePtr[T any](vs []T) *[]T {
+	if vs == nil {
+		return nil
+	}
+	out := make([]T, len(vs))
+	copy(out, vs)
+	return &out
+}
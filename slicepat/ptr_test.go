@@ -0,0 +1,262 @@
+package slicepat
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDefragment(t *testing.T) {
+	a, b, c := &user{ID: 1}, &user{ID: 2}, &user{ID: 3}
+	src := []*user{nil, a, nil, b, nil, c, nil}
+
+	got, freed := Defragment(src)
+	if freed != 4 {
+		t.Errorf("freed = %d, want 4", freed)
+	}
+	if len(got) != 3 || got[0] != a || got[1] != b || got[2] != c {
+		t.Errorf("compacted = %v, want [a b c]", got)
+	}
+	if &got[0] != &src[0] {
+		t.Error("expected src backing array to be reused")
+	}
+	for i := len(got); i < len(src); i++ {
+		if src[i] != nil {
+			t.Errorf("src[%d] = %v, want nil tail", i, src[i])
+		}
+	}
+}
+
+func TestDeepCopyShared(t *testing.T) {
+	shared := &user{ID: 1, Name: "Alice"}
+	other := &user{ID: 2, Name: "Bob"}
+	src := []*user{shared, other, nil, shared}
+
+	got := DeepCopyShared(src)
+	if len(got) != len(src) {
+		t.Fatalf("len = %d, want %d", len(got), len(src))
+	}
+	if got[0] == shared || got[1] == other {
+		t.Error("copies must not alias the originals")
+	}
+	if got[0] != got[3] {
+		t.Error("slots sharing one original should share one copy")
+	}
+	if got[2] != nil {
+		t.Error("nil slot should stay nil")
+	}
+
+	got[0].Name = "Alice-Copied"
+	if shared.Name != "Alice" {
+		t.Errorf("original modified: %q", shared.Name)
+	}
+	if got[3].Name != "Alice-Copied" {
+		t.Errorf("got[3].Name = %q, want shared update", got[3].Name)
+	}
+}
+
+func TestFillNil(t *testing.T) {
+	alice := &user{ID: 1, Name: "Alice"}
+	src := []*user{alice, nil, nil}
+
+	FillNil(src, func(i int) *user { return &user{ID: uint(100 + i)} })
+	if src[0] != alice {
+		t.Error("non-nil element was replaced")
+	}
+	if src[1] == nil || src[2] == nil {
+		t.Fatal("nil slots were not filled")
+	}
+	if src[1] == src[2] {
+		t.Error("filled slots should be distinct objects")
+	}
+	if src[1].ID != 101 || src[2].ID != 102 {
+		t.Errorf("IDs = %d, %d, want 101, 102", src[1].ID, src[2].ID)
+	}
+}
+
+func TestCompactDeref(t *testing.T) {
+	src := []*user{nil, {ID: 1, Name: "Alice"}, nil, {ID: 2, Name: "Bob"}, nil}
+	got := CompactDeref(src)
+	if want := []user{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	got[0].Name = "changed"
+	if src[1].Name != "Alice" {
+		t.Error("result should hold copies, not aliases")
+	}
+
+	if got := CompactDeref([]*user{nil, nil}); got == nil || len(got) != 0 {
+		t.Errorf("all nil: got %v, want empty non-nil slice", got)
+	}
+}
+
+func TestToPtrSlice(t *testing.T) {
+	src := genUsers(3)
+	got := ToPtrSlice(src)
+	if len(got) != len(src) {
+		t.Fatalf("len = %d, want %d", len(got), len(src))
+	}
+	for i := range src {
+		if *got[i] != src[i] {
+			t.Errorf("*got[%d] = %v, want %v", i, *got[i], src[i])
+		}
+		if got[i] == &src[i] {
+			t.Errorf("got[%d] points into the source backing array", i)
+		}
+	}
+
+	got[0].Name = "changed"
+	if src[0].Name != "User_0" {
+		t.Errorf("src[0].Name = %q, mutation through pointer leaked into source", src[0].Name)
+	}
+}
+
+func TestToValueSlice(t *testing.T) {
+	alice, bob := &user{ID: 1, Name: "Alice"}, &user{ID: 2, Name: "Bob"}
+	tests := []struct {
+		name string
+		src  []*user
+		want []user
+	}{
+		{"mixed", []*user{alice, nil, bob, nil}, []user{*alice, *bob}},
+		{"all nil", []*user{nil, nil, nil}, []user{}},
+		{"empty", nil, []user{}},
+	}
+	for _, tt := range tests {
+		got := ToValueSlice(tt.src)
+		if got == nil {
+			t.Errorf("%s: got nil, want non-nil slice", tt.name)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		if b, _ := json.Marshal(got); len(tt.want) == 0 && string(b) != "[]" {
+			t.Errorf("%s: marshaled to %s, want []", tt.name, b)
+		}
+	}
+}
+
+func TestDeepCopyPtrSlice(t *testing.T) {
+	src := []*user{{ID: 100, Name: "X"}, nil, {ID: 101, Name: "Y"}}
+	got := DeepCopyPtrSlice(src)
+	if len(got) != len(src) {
+		t.Fatalf("len = %d, want %d", len(got), len(src))
+	}
+	if got[1] != nil {
+		t.Error("nil at index 1 should stay nil in the copy")
+	}
+	if !EqualDeepPtr(got, src) {
+		t.Error("copy should equal the source by content")
+	}
+
+	got[0].Name = "X-Changed-Outside"
+	got[2].City = "Tokyo"
+	if src[0].Name != "X" || src[2].City != "" {
+		t.Errorf("source modified through copy: %v, %v", *src[0], *src[2])
+	}
+	if src[1] != nil {
+		t.Error("source nil slot changed")
+	}
+}
+
+func TestCompactNonNil(t *testing.T) {
+	a, b := &user{ID: 1}, &user{ID: 2}
+	src := []*user{nil, a, nil, b}
+	got := CompactNonNil(src)
+	if len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("got %v, want [a b]", got)
+	}
+	if src[0] != nil || src[1] != a {
+		t.Error("source was modified")
+	}
+	if got := CompactNonNil([]*user{nil, nil}); got == nil || len(got) != 0 {
+		t.Errorf("all nil: got %v, want empty non-nil slice", got)
+	}
+}
+
+func TestCompactNonNilInPlace(t *testing.T) {
+	a, b, c := &user{ID: 1}, &user{ID: 2}, &user{ID: 3}
+	src := []*user{a, nil, b, nil, nil, c}
+	got := CompactNonNilInPlace(src)
+	if len(got) != 3 || got[0] != a || got[1] != b || got[2] != c {
+		t.Errorf("got %v, want [a b c]", got)
+	}
+	if &got[0] != &src[0] {
+		t.Error("expected input backing array to be reused")
+	}
+	for i, p := range src[len(got):] {
+		if p != nil {
+			t.Errorf("tail[%d] = %v, want nil so the old pointer can be collected", i, p)
+		}
+	}
+}
+
+func TestDeepCopyInto(t *testing.T) {
+	src := []*user{{ID: 1, Name: "Alice"}, nil, {ID: 3, Name: "Carol"}}
+	reused := &user{ID: 99}
+	dst := []*user{reused, {ID: 98}, nil}
+	assign := func(d, s *user) { *d = *s }
+
+	DeepCopyInto(dst, src, assign)
+	if dst[0] != reused {
+		t.Error("existing dst storage should be reused")
+	}
+	if dst[1] != nil {
+		t.Error("nil src element should produce nil dst slot")
+	}
+	if dst[2] == nil || dst[2] == src[2] {
+		t.Error("nil dst slot should get fresh storage")
+	}
+	if !EqualDeepPtr(dst, src) {
+		t.Error("dst should equal src by content")
+	}
+
+	dst[0].Name = "changed"
+	if src[0].Name != "Alice" {
+		t.Error("src modified through dst")
+	}
+}
+
+func TestPatternConversions_RoundTrip(t *testing.T) {
+	a := genUsers(5)
+	orig := append([]user(nil), a...)
+
+	// A → C → A
+	c := ValueSliceToPtrElems(a)
+	back := PtrElemsToValueSlice(c)
+	if !reflect.DeepEqual(back, orig) {
+		t.Errorf("A→C→A: got %v, want %v", back, orig)
+	}
+
+	// 変換後のどちらを変更しても元の配列には影響しない
+	c[0].Name = "changed via C"
+	back[1].Name = "changed via A"
+	if !reflect.DeepEqual(a, orig) {
+		t.Errorf("original modified: %v", a)
+	}
+
+	// nil 要素はゼロ値として位置を保つ
+	got := PtrElemsToValueSlice([]*user{c[2], nil, c[3]})
+	if len(got) != 3 || got[1] != (user{}) || got[2] != orig[3] {
+		t.Errorf("nil element: got %v", got)
+	}
+}
+
+func TestValueSliceToSlicePtr(t *testing.T) {
+	a := genUsers(3)
+	b := ValueSliceToSlicePtr(a)
+	if b == nil || !reflect.DeepEqual(*b, a) {
+		t.Fatalf("got %v, want %v", b, a)
+	}
+	(*b)[0].Name = "changed"
+	if a[0].Name == "changed" {
+		t.Error("result shares backing array with src")
+	}
+
+	if got := ValueSliceToSlicePtr[user](nil); got != nil {
+		t.Errorf("nil: got %v, want nil pointer", got)
+	}
+	if got := ValueSliceToSlicePtr([]user{}); got == nil || *got == nil || len(*got) != 0 {
+		t.Errorf("empty: got %v, want pointer to empty slice", got)
+	}
+}
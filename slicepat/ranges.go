@@ -0,0 +1,53 @@
+package slicepat
+
+import "sort"
+
+// Range は Start 以上 End 以下（両端を含む）の整数の範囲。
+type Range struct {
+	Start, End int
+}
+
+// ToRanges は連続する整数をまとめた Range の列を返す（[1 2 3 5] → [{1 3} {5 5}]）。
+// 入力はソート済みでなくてよい。コピーをソートして重複を無視するので src は変更しない。
+// ユーザーIDの集合をコンパクトに表示する用途を想定している。
+func ToRanges(src []int) []Range {
+	sorted := append([]int(nil), src...)
+	sort.Ints(sorted)
+	out := []Range{}
+	for _, v := range sorted {
+		// End+1 は End == math.MaxInt で溢れるので v-1 と比べる（v == math.MinInt なら前の条件で決まる）
+		if n := len(out); n > 0 && (v <= out[n-1].End || v-1 == out[n-1].End) {
+			out[n-1].End = max(out[n-1].End, v)
+			continue
+		}
+		out = append(out, Range{Start: v, End: v})
+	}
+	return out
+}
+
+// ExpandRanges は Range の列を展開した整数スライスを返す。ToRanges の逆変換で、
+// ExpandRanges(ToRanges(s)) は s をソートして重複を除いたものになる。
+// Start > End の Range は空として扱う。
+func ExpandRanges(ranges []Range) []int {
+	n := 0
+	for _, r := range ranges {
+		if r.End >= r.Start {
+			// End - Start は int では溢れうるので符号なしで数える
+			n += int(uint(r.End)-uint(r.Start)) + 1
+		}
+	}
+	out := make([]int, 0, n)
+	for _, r := range ranges {
+		if r.End < r.Start {
+			continue
+		}
+		// v <= End で回すと End == math.MaxInt のとき v++ が一周して終わらない
+		for v := r.Start; ; v++ {
+			out = append(out, v)
+			if v == r.End {
+				break
+			}
+		}
+	}
+	return out
+}
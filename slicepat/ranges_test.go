@@ -0,0 +1,49 @@
+package slicepat
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestToRanges(t *testing.T) {
+	tests := []struct {
+		src  []int
+		want []Range
+	}{
+		{[]int{1, 2, 3, 5}, []Range{{1, 3}, {5, 5}}},
+		{[]int{10, 3, 1, 2, 11, 7, 2}, []Range{{1, 3}, {7, 7}, {10, 11}}},
+		{[]int{-2, -1, 0, 4}, []Range{{-2, 0}, {4, 4}}},
+		{nil, []Range{}},
+		{[]int{math.MaxInt, math.MaxInt - 1, math.MaxInt}, []Range{{math.MaxInt - 1, math.MaxInt}}},
+		{[]int{math.MinInt, math.MinInt, math.MinInt + 1}, []Range{{math.MinInt, math.MinInt + 1}}},
+	}
+	for _, tt := range tests {
+		if got := ToRanges(tt.src); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ToRanges(%v) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestExpandRanges_RoundTrip(t *testing.T) {
+	src := []int{9, 1, 2, 3, 5, 3, 8, 10, 20}
+	want := []int{1, 2, 3, 5, 8, 9, 10, 20}
+	if got := ExpandRanges(ToRanges(src)); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandRanges(ToRanges(%v)) = %v, want %v", src, got, want)
+	}
+
+	if got := ExpandRanges([]Range{{3, 1}}); len(got) != 0 {
+		t.Errorf("inverted range: got %v, want empty", got)
+	}
+}
+
+func TestExpandRanges_IntBoundaries(t *testing.T) {
+	ranges := []Range{{math.MinInt, math.MinInt + 1}, {math.MaxInt - 1, math.MaxInt}}
+	want := []int{math.MinInt, math.MinInt + 1, math.MaxInt - 1, math.MaxInt}
+	if got := ExpandRanges(ranges); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := ExpandRanges(ToRanges(want)); !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,137 @@
+package slicepat
+
+import (
+	"cmp"
+	"sort"
+)
+
+// SortByOptional は key の値で src をその場で昇順に並べ替える。
+// key が nil を返す要素（値なし）は末尾に回す。*time.Time のような任意項目での並べ替えに使う。
+// 安定ソートなので、キーが等しい要素や nil キーの要素は元の相対順を保つ。
+func SortByOptional[T any, K cmp.Ordered](src []T, key func(T) *K) {
+	sort.SliceStable(src, func(i, j int) bool {
+		ki, kj := key(src[i]), key(src[j])
+		switch {
+		case ki == nil:
+			return false
+		case kj == nil:
+			return true
+		default:
+			return *ki < *kj
+		}
+	})
+}
+
+// SortPtrStableKeepNil は src の非 nil 要素だけを less で安定ソートし、nil は元の位置に残す
+// （穴をよけて並べ替える）。nil が位置のプレースホルダーになっている場合に使う。src をその場で変更する。
+func SortPtrStableKeepNil[T any](src []*T, less func(a, b *T) bool) {
+	vals := make([]*T, 0, len(src))
+	for _, p := range src {
+		if p != nil {
+			vals = append(vals, p)
+		}
+	}
+	sort.SliceStable(vals, func(i, j int) bool { return less(vals[i], vals[j]) })
+	k := 0
+	for i, p := range src {
+		if p != nil {
+			src[i] = vals[k]
+			k++
+		}
+	}
+}
+
+// NilSafeLess は less を包み、nil を末尾に回す比較関数を返す。
+// 両方 nil なら false（等しい扱い）、片方だけ nil なら非 nil の方が前になる。
+// less に nil は渡らない。
+func NilSafeLess[T any](less func(a, b *T) bool) func(a, b *T) bool {
+	return func(a, b *T) bool {
+		switch {
+		case a == nil:
+			return false
+		case b == nil:
+			return true
+		default:
+			return less(a, b)
+		}
+	}
+}
+
+// SortPtrSliceStable は ps を less でその場で安定ソートし、nil を末尾に集める（NilSafeLess を使う）。
+// 等しい要素は元の相対順を保つので、副キーから順にソートを重ねる使い方ができる。
+func SortPtrSliceStable[T any](ps []*T, less func(a, b *T) bool) {
+	nilSafe := NilSafeLess(less)
+	sort.SliceStable(ps, func(i, j int) bool { return nilSafe(ps[i], ps[j]) })
+}
+
+// NthElement は less の順で k 番目（0 始まり）に小さい要素を返す。全体をソートせずに
+// クイックセレクト（平均 O(n)）で求める。作業はコピー上で行うので src は変更しない。
+// k が範囲外なら ok は false。ユーザー年齢の中央値を求めるような用途向け。
+func NthElement[T any](src []T, k int, less func(a, b T) bool) (T, bool) {
+	if k < 0 || k >= len(src) {
+		var zero T
+		return zero, false
+	}
+	work := append([]T(nil), src...)
+	lo, hi := 0, len(work)-1
+	for lo < hi {
+		// 中央の要素をピボットにして3分割する（Dutch national flag）。
+		// ピボットと等しい要素を1か所に集めるので、重複が多い入力でも2乗にならない。
+		pivot := work[lo+(hi-lo)/2]
+		lt, i, gt := lo, lo, hi
+		for i <= gt {
+			switch {
+			case less(work[i], pivot):
+				work[lt], work[i] = work[i], work[lt]
+				lt++
+				i++
+			case less(pivot, work[i]):
+				work[i], work[gt] = work[gt], work[i]
+				gt--
+			default:
+				i++
+			}
+		}
+		// [lo,lt) < pivot, [lt,gt] == pivot, (gt,hi] > pivot
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			return work[k], true
+		}
+	}
+	return work[k], true
+}
+
+// Comparator は a と b を比べ、a が前なら負、後なら正、同順なら 0 を返す比較関数。
+// slices.SortFunc にそのまま渡せ、Less で sort.Slice などに使う less 関数にもなる。
+// By で作って ThenBy でつなぐと、複数キーの並べ替えを前のキーから順に書ける。
+//
+//	c := slicepat.By(func(u User) string { return u.City }).
+//		ThenBy(slicepat.By(func(u User) uint { return u.Age })).
+//		ThenBy(slicepat.By(func(u User) string { return u.Name }))
+//	sort.SliceStable(users, func(i, j int) bool { return c.Less(users[i], users[j]) })
+type Comparator[T any] func(a, b T) int
+
+// By は key の値の昇順で比べる Comparator を返す。
+// メソッドは型パラメータを持てないので、キーの型が異なる2つ目以降も By で作って ThenBy に渡す。
+func By[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int { return cmp.Compare(key(a), key(b)) }
+}
+
+// ThenBy は c で同順になった要素を next で比べる Comparator を返す。
+func (c Comparator[T]) ThenBy(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// Less は a が b より前なら true を返す。
+func (c Comparator[T]) Less(a, b T) bool {
+	return c(a, b) < 0
+}
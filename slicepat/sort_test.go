@@ -0,0 +1,178 @@
+package slicepat
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSortByOptional(t *testing.T) {
+	type member struct {
+		Name  string
+		Score *int
+	}
+	score := func(v int) *int { return &v }
+	src := []member{
+		{"a", nil},
+		{"b", score(30)},
+		{"c", score(10)},
+		{"d", nil},
+		{"e", score(20)},
+		{"f", score(10)},
+	}
+	SortByOptional(src, func(m member) *int { return m.Score })
+
+	var names []string
+	for _, m := range src {
+		names = append(names, m.Name)
+	}
+	if want := []string{"c", "f", "e", "b", "a", "d"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %v, want %v", names, want)
+	}
+}
+
+func TestSortPtrStableKeepNil(t *testing.T) {
+	mk := func(id uint, name string) *user { return &user{ID: id, Name: name} }
+	src := []*user{mk(1, "Dave"), nil, mk(2, "Bob"), mk(3, "Carol"), nil, mk(4, "Alice"), mk(5, "Bob")}
+	byName := func(a, b *user) bool { return a.Name < b.Name }
+
+	SortPtrStableKeepNil(src, byName)
+	if src[1] != nil || src[4] != nil {
+		t.Error("nil positions moved")
+	}
+	var got []uint
+	for _, p := range src {
+		if p != nil {
+			got = append(got, p.ID)
+		}
+	}
+	// Bob(2) と Bob(5) は元の順を保つ
+	if want := []uint{4, 2, 5, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IDs = %v, want %v", got, want)
+	}
+}
+
+func TestNilSafeLess(t *testing.T) {
+	mk := func(id uint, name string) *user { return &user{ID: id, Name: name} }
+	src := []*user{nil, mk(1, "Carol"), nil, mk(2, "Alice"), nil, mk(3, "Bob"), nil}
+	less := NilSafeLess(func(a, b *user) bool { return a.Name < b.Name })
+
+	sort.SliceStable(src, func(i, j int) bool { return less(src[i], src[j]) })
+	var names []string
+	for _, p := range src[:3] {
+		if p == nil {
+			t.Fatalf("nil among the first elements: %v", src)
+		}
+		names = append(names, p.Name)
+	}
+	if want := []string{"Alice", "Bob", "Carol"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+	for i, p := range src[3:] {
+		if p != nil {
+			t.Errorf("tail[%d] = %v, want nil", i, p)
+		}
+	}
+
+	if less(nil, nil) {
+		t.Error("less(nil, nil) = true, want false")
+	}
+}
+
+func TestSortPtrSliceStable(t *testing.T) {
+	mk := func(id uint, name string) *user { return &user{ID: id, Name: name} }
+	src := []*user{mk(1, "Bob"), nil, mk(2, "Alice"), mk(3, "Bob"), nil, mk(4, "Alice"), mk(5, "Bob")}
+
+	SortPtrSliceStable(src, func(a, b *user) bool { return a.Name < b.Name })
+	var ids []uint
+	for _, p := range src[:5] {
+		if p == nil {
+			t.Fatalf("nil before the tail: %v", src)
+		}
+		ids = append(ids, p.ID)
+	}
+	// 同名同士は元の順（Alice: 2,4 / Bob: 1,3,5）
+	if want := []uint{2, 4, 1, 3, 5}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("IDs = %v, want %v", ids, want)
+	}
+	if src[5] != nil || src[6] != nil {
+		t.Error("nils should be at the tail")
+	}
+}
+
+func TestNthElement(t *testing.T) {
+	src := genUsers(501)
+	// 年齢に偏りと重複を持たせる
+	for i := range src {
+		src[i].Age = uint((i * 7919) % 97)
+	}
+	orig := append([]user(nil), src...)
+	byAge := func(a, b user) bool { return a.Age < b.Age }
+
+	sorted := append([]user(nil), src...)
+	sort.SliceStable(sorted, func(i, j int) bool { return byAge(sorted[i], sorted[j]) })
+
+	for _, k := range []int{0, 1, 100, 250, 499, 500} {
+		got, ok := NthElement(src, k, byAge)
+		if !ok || got.Age != sorted[k].Age {
+			t.Errorf("k=%d: got age %d (ok=%v), want %d", k, got.Age, ok, sorted[k].Age)
+		}
+	}
+	if !reflect.DeepEqual(src, orig) {
+		t.Error("src was modified")
+	}
+	if _, ok := NthElement(src, len(src), byAge); ok {
+		t.Error("k out of range: ok = true")
+	}
+	if _, ok := NthElement([]user(nil), 0, byAge); ok {
+		t.Error("empty: ok = true")
+	}
+}
+
+func TestNthElement_ManyEqual(t *testing.T) {
+	// 全員同じ年齢でも比較回数は要素数に比例する（2乗にならない）
+	const n = 20000
+	src := make([]int, n)
+	for i := range src {
+		src[i] = 30
+	}
+	src[n/3] = 10
+	calls := 0
+	less := func(a, b int) bool { calls++; return a < b }
+
+	if got, ok := NthElement(src, n/2, less); !ok || got != 30 {
+		t.Errorf("median: got %d (ok=%v), want 30", got, ok)
+	}
+	if got, _ := NthElement(src, 0, less); got != 10 {
+		t.Errorf("min: got %d, want 10", got)
+	}
+	if calls > 10*n {
+		t.Errorf("less called %d times for %d elements", calls, n)
+	}
+}
+
+func TestComparator(t *testing.T) {
+	src := []user{
+		{Name: "Dave", Age: 30, City: "Tokyo"},
+		{Name: "Carol", Age: 30, City: "Sendai"},
+		{Name: "Bob", Age: 25, City: "Sendai"},
+		{Name: "Alice", Age: 30, City: "Sendai"},
+		{Name: "Eve", Age: 20, City: "Tokyo"},
+	}
+	c := By(userCity).
+		ThenBy(By(func(u user) uint { return u.Age })).
+		ThenBy(By(func(u user) string { return u.Name }))
+	sort.Slice(src, func(i, j int) bool { return c.Less(src[i], src[j]) })
+
+	// Sendai の中は年齢で、Sendai・30 歳の Alice と Carol は名前で並ぶ
+	if got, want := userNames(src), []string{"Bob", "Alice", "Carol", "Eve", "Dave"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := c(src[1], src[1]); got != 0 {
+		t.Errorf("compare equal: got %d, want 0", got)
+	}
+	if c.Less(src[2], src[1]) {
+		t.Error("Less(Carol, Alice) = true, want false")
+	}
+}
@@ -0,0 +1,49 @@
+package slicepat
+
+import (
+	"math"
+	"sort"
+)
+
+// Stats は value の値の最小・最大・平均・標準偏差（母標準偏差）を1パスで計算する。
+// 平均と分散は Welford 法で求めるので、値が大きくても桁落ちしにくい。
+// 空スライスではすべて 0 を返す。
+func Stats[T any](src []T, value func(T) float64) (minV, maxV, mean, stddev float64) {
+	if len(src) == 0 {
+		return 0, 0, 0, 0
+	}
+	var m2 float64
+	for i, v := range src {
+		x := value(v)
+		if i == 0 {
+			minV, maxV = x, x
+		} else {
+			minV, maxV = min(minV, x), max(maxV, x)
+		}
+		delta := x - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (x - mean)
+	}
+	return minV, maxV, mean, math.Sqrt(m2 / float64(len(src)))
+}
+
+// Percentile は value の値の第 p パーセンタイル（p は 0〜100）を返す。
+// 値のコピーをソートし、隣り合う順位の間を線形補間する（p=50 で中央値）。src は変更しない。
+// 空スライスでは 0 を返す。p が範囲外なら panic する。
+func Percentile[T any](src []T, value func(T) float64, p float64) float64 {
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		panic("slicepat: Percentile: p must be within [0, 100]")
+	}
+	if len(src) == 0 {
+		return 0
+	}
+	xs := make([]float64, len(src))
+	for i, v := range src {
+		xs[i] = value(v)
+	}
+	sort.Float64s(xs)
+	rank := p / 100 * float64(len(xs)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return xs[lo] + (xs[hi]-xs[lo])*(rank-float64(lo))
+}
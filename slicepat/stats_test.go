@@ -0,0 +1,62 @@
+package slicepat
+
+import (
+	"math"
+	"testing"
+)
+
+func userAge(u user) float64 { return float64(u.Age) }
+
+func TestStats(t *testing.T) {
+	// 2,4,4,4,5,5,7,9: 平均 5, 母標準偏差 2
+	var src []user
+	for _, age := range []uint{2, 4, 4, 4, 5, 5, 7, 9} {
+		src = append(src, user{Age: age})
+	}
+	lo, hi, mean, stddev := Stats(src, userAge)
+	if lo != 2 || hi != 9 {
+		t.Errorf("min, max = %v, %v, want 2, 9", lo, hi)
+	}
+	if math.Abs(mean-5) > 1e-9 || math.Abs(stddev-2) > 1e-9 {
+		t.Errorf("mean, stddev = %v, %v, want 5, 2", mean, stddev)
+	}
+
+	lo, hi, mean, stddev = Stats([]user(nil), userAge)
+	if lo != 0 || hi != 0 || mean != 0 || stddev != 0 {
+		t.Errorf("empty: got %v %v %v %v, want zeros", lo, hi, mean, stddev)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	// 年齢 10,20,...,100 をシャッフルした並び
+	var src []user
+	for _, age := range []uint{70, 10, 100, 40, 30, 90, 20, 60, 50, 80} {
+		src = append(src, user{Age: age})
+	}
+	tests := []struct {
+		p, want float64
+	}{
+		{0, 10},
+		{50, 55},
+		{100, 100},
+		{25, 32.5},
+		{90, 91},
+	}
+	for _, tt := range tests {
+		if got := Percentile(src, userAge, tt.p); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Percentile(p=%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if src[0].Age != 70 {
+		t.Error("src was reordered")
+	}
+}
+
+func TestPercentile_InvalidP(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for p > 100")
+		}
+	}()
+	Percentile(demoUsers, userAge, 101)
+}
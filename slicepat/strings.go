@@ -0,0 +1,23 @@
+package slicepat
+
+import "strings"
+
+// joinEstimate は JoinString が1要素あたりに見込む文字列長。
+const joinEstimate = 16
+
+// JoinString は src の各要素を str で文字列にし、sep で区切って連結する（ユーザー名のカンマ区切り一覧など）。
+// strings.Builder を1つだけ使い、要素数から見積もった容量を最初に確保する。空なら "" を返す。
+func JoinString[T any](src []T, sep string, str func(T) string) string {
+	if len(src) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.Grow(len(src)*joinEstimate + (len(src)-1)*len(sep))
+	for i, v := range src {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(str(v))
+	}
+	return b.String()
+}
@@ -0,0 +1,16 @@
+package slicepat
+
+import "testing"
+
+func TestJoinString(t *testing.T) {
+	name := func(u user) string { return u.Name }
+	if got, want := JoinString(demoUsers[:3], ", ", name), "Alice, Bob, Carol"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := JoinString(demoUsers[:1], ", ", name); got != "Alice" {
+		t.Errorf("single: got %q", got)
+	}
+	if got := JoinString([]user(nil), ", ", name); got != "" {
+		t.Errorf("empty: got %q, want empty", got)
+	}
+}
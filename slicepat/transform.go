@@ -0,0 +1,135 @@
+package slicepat
+
+// Map は s の各要素に f を適用した結果を、同じ順で新しいスライスに入れて返す。
+// 出力は len(s) で確保してインデックスで代入するので、append による再確保は起きない。
+func Map[T, U any](s []T, f func(T) U) []U {
+	out := make([]U, len(s))
+	for i, v := range s {
+		out[i] = f(v)
+	}
+	return out
+}
+
+// ConvertAll は失敗しうる conv で s の全要素を変換する（[]User から []DTO への変換など）。
+// 最初に失敗した時点で打ち切り、nil とその位置を持つ IndexedError を返す。
+// 元のエラーは errors.Is / errors.As でたどれる。
+func ConvertAll[T, U any](s []T, conv func(T) (U, error)) ([]U, error) {
+	out := make([]U, len(s))
+	for i, v := range s {
+		u, err := conv(v)
+		if err != nil {
+			return nil, IndexedError{Index: i, Err: err}
+		}
+		out[i] = u
+	}
+	return out, nil
+}
+
+// ConvertAllLenient は ConvertAll と違い失敗しても最後まで変換を続け、
+// 成功した結果（s での順を保つ）と、失敗した要素ごとのエラーを返す。
+// 失敗がなければ errs は nil。まとめて1つの error にするなら JoinIndexedErrors を使う。
+func ConvertAllLenient[T, U any](s []T, conv func(T) (U, error)) (ok []U, errs []IndexedError) {
+	ok = make([]U, 0, len(s))
+	for i, v := range s {
+		u, err := conv(v)
+		if err != nil {
+			errs = append(errs, IndexedError{Index: i, Err: err})
+			continue
+		}
+		ok = append(ok, u)
+	}
+	return ok, errs
+}
+
+// Reduce は init から始めて s の各要素を順に f で畳み込んだ結果を返す。空なら init をそのまま返す。
+// Reduce 自身はアロケーションしない。
+func Reduce[T, Acc any](s []T, init Acc, f func(Acc, T) Acc) Acc {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// MapPtr は ps の nil 要素を読み飛ばし、残りに f を適用した結果を返す。
+// f に nil は渡らないので、nil が混入したスライスでも panic しない。結果は len(ps) より短くなりうる。
+// 長さと位置を保ちたければ MapPtrOrZero を、nil の扱いを f の側で決めたければ Map を使う。
+func MapPtr[T, U any](ps []*T, f func(*T) U) []U {
+	out := make([]U, 0, len(ps))
+	for _, p := range ps {
+		if p != nil {
+			out = append(out, f(p))
+		}
+	}
+	return out
+}
+
+// MapPtrSkipNil は MapPtr と同じ。nil を読み飛ばすことを名前で明示したい呼び出し側のために残している。
+func MapPtrSkipNil[T, U any](ps []*T, f func(*T) U) []U {
+	return MapPtr(ps, f)
+}
+
+// MapPtrOrZero は ps の nil 要素を U のゼロ値にし、残りに f を適用した結果を返す。
+// f に nil は渡らず、MapPtr と違って結果の長さと位置は ps と一致する。
+func MapPtrOrZero[T, U any](ps []*T, f func(*T) U) []U {
+	out := make([]U, len(ps))
+	for i, p := range ps {
+		if p != nil {
+			out[i] = f(p)
+		}
+	}
+	return out
+}
+
+// MapReuse は src の各要素に f を適用した結果を dst のバッファに書き込んで返す。
+// dst の容量が足りなければ新しく確保し直す。ホットループで dst を使い回すと
+// 呼び出しごとのアロケーションを避けられる。
+// 戻り値は dst と同じ配列を指すことがあるので、次の呼び出しまでに必要な値は複製しておくこと。
+func MapReuse[T, U any](dst []U, src []T, f func(T) U) []U {
+	if cap(dst) < len(src) {
+		dst = make([]U, len(src))
+	} else {
+		dst = dst[:len(src)]
+	}
+	for i, v := range src {
+		dst[i] = f(v)
+	}
+	return dst
+}
+
+// Tap は f に src を渡して呼び、src をそのまま返す。処理の途中にログ出力などを差し込むためのもの。
+// f が src を変更しないのは呼び出し側の責任。
+func Tap[T any](src []T, f func([]T)) []T {
+	f(src)
+	return src
+}
+
+// TapEach は src の各要素について f を呼び、src をそのまま返す。
+func TapEach[T any](src []T, f func(T)) []T {
+	for _, v := range src {
+		f(v)
+	}
+	return src
+}
+
+// ForEach は s の各要素についてインデックスと値を渡して f を呼ぶ。副作用のための反復に使う。
+func ForEach[T any](s []T, f func(i int, v T)) {
+	for i, v := range s {
+		f(i, v)
+	}
+}
+
+// ForEachPtr は ps の nil でない要素についてだけ f を呼ぶ。f に nil は渡らない。
+// i は ps での位置なので、nil を飛ばした分だけ番号が飛ぶ。
+func ForEachPtr[T any](ps []*T, f func(i int, p *T)) {
+	for i, p := range ps {
+		if p != nil {
+			f(i, p)
+		}
+	}
+}
+
+// ForEachPtrIncludeNil は nil 要素も含めて ps の全要素について f を呼ぶ。nil の扱いは f の側で決める。
+func ForEachPtrIncludeNil[T any](ps []*T, f func(i int, p *T)) {
+	ForEach(ps, f)
+}
@@ -0,0 +1,231 @@
+package slicepat
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	us := genUsers(21)
+	src := []user{us[1], us[10], us[20]} // 19, 28, 38 歳
+	got := Map(src, toDTO)
+	want := []dto{
+		{Identifier: "user1@example.com", AgeGroup: "teen"},
+		{Identifier: "user10@example.com", AgeGroup: "20s"},
+		{Identifier: "user20@example.com", AgeGroup: "30s"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := Map([]user(nil), toDTO); got == nil || len(got) != 0 {
+		t.Errorf("empty input: got %v, want empty", got)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sumAge := func(acc uint, u user) uint { return acc + u.Age }
+	if got := Reduce(demoUsers, 0, sumAge); got != 214 {
+		t.Errorf("sum of ages = %d, want 214", got)
+	}
+
+	joinName := func(acc string, u user) string {
+		if acc == "" {
+			return u.Name
+		}
+		return acc + "," + u.Name
+	}
+	if got, want := Reduce(demoUsers[:3], "", joinName), "Alice,Bob,Carol"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := Reduce([]user(nil), uint(42), sumAge); got != 42 {
+		t.Errorf("empty: got %d, want init 42", got)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { Reduce(demoUsers, 0, sumAge) }); allocs != 0 {
+		t.Errorf("Reduce allocated %v times per run, want 0", allocs)
+	}
+}
+
+func TestMapPtr(t *testing.T) {
+	alice, bob := &user{Name: "Alice"}, &user{Name: "Bob"}
+	src := []*user{nil, alice, nil, bob, nil}
+
+	// f が nil を受け取れば u.Name で panic するので、nil が渡らないことも確かめられる
+	got := MapPtr(src, func(u *user) string { return u.Name })
+	if want := []string{"Alice", "Bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MapPtr: got %v, want %v", got, want)
+	}
+
+	// nil を f の側で扱いたい場合は Map を使う
+	got = Map(src, func(u *user) string {
+		if u == nil {
+			return "<nil>"
+		}
+		return u.Name
+	})
+	if want := []string{"<nil>", "Alice", "<nil>", "Bob", "<nil>"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Map: got %v, want %v", got, want)
+	}
+
+	got = MapPtrSkipNil(src, func(u *user) string { return u.Name })
+	if want := []string{"Alice", "Bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MapPtrSkipNil: got %v, want %v", got, want)
+	}
+	if got := MapPtrSkipNil([]*user{nil}, func(u *user) string { return u.Name }); got == nil || len(got) != 0 {
+		t.Errorf("MapPtrSkipNil all nil: got %v, want empty", got)
+	}
+
+	got = MapPtrOrZero(src, func(u *user) string { return u.Name })
+	if want := []string{"", "Alice", "", "Bob", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MapPtrOrZero: got %v, want %v", got, want)
+	}
+}
+
+func TestMapReuse(t *testing.T) {
+	src := []int{1, 2, 3}
+	double := func(v int) int { return v * 2 }
+
+	// 容量不足なら新しく確保する
+	got := MapReuse(nil, src, double)
+	if want := []int{2, 4, 6}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// 容量が足りれば同じ配列に書き込む
+	buf := make([]int, 0, 8)
+	got = MapReuse(buf, src, double)
+	if &got[0] != &buf[:1][0] {
+		t.Error("expected dst backing array to be reused")
+	}
+	if len(got) != len(src) {
+		t.Errorf("len = %d, want %d", len(got), len(src))
+	}
+
+	// 前回より短い入力では長さが縮む
+	got = MapReuse(got, src[:1], double)
+	if want := []int{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestTap(t *testing.T) {
+	src := genUsers(5)
+	orig := append([]user(nil), src...)
+
+	var seenLen int
+	got := Tap(src, func(us []user) { seenLen = len(us) })
+	if seenLen != len(src) {
+		t.Errorf("f saw len %d, want %d", seenLen, len(src))
+	}
+	if &got[0] != &src[0] || !reflect.DeepEqual(got, orig) {
+		t.Error("Tap should return src unchanged")
+	}
+
+	var names []string
+	got = TapEach(src, func(u user) { names = append(names, u.Name) })
+	if !reflect.DeepEqual(names, userNames(orig)) {
+		t.Errorf("TapEach visited %v", names)
+	}
+	if &got[0] != &src[0] || !reflect.DeepEqual(got, orig) {
+		t.Error("TapEach should return src unchanged")
+	}
+}
+
+var errNoEmail = errors.New("no email")
+
+func userEmail(u user) (string, error) {
+	if u.Email == "" {
+		return "", errNoEmail
+	}
+	return u.Email, nil
+}
+
+func TestConvertAll(t *testing.T) {
+	src := genUsers(3)
+	got, err := ConvertAll(src, userEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{src[0].Email, src[1].Email, src[2].Email}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	src[1].Email = ""
+	src[2].Email = ""
+	got, err = ConvertAll(src, userEmail)
+	if got != nil {
+		t.Errorf("got %v, want nil on error", got)
+	}
+	var ie IndexedError
+	if !errors.As(err, &ie) || ie.Index != 1 {
+		t.Errorf("got %v, want IndexedError at index 1", err)
+	}
+	if !errors.Is(err, errNoEmail) {
+		t.Errorf("got %v, want wrapped errNoEmail", err)
+	}
+}
+
+func TestConvertAllLenient(t *testing.T) {
+	src := genUsers(4)
+	src[1].Email = ""
+	src[3].Email = ""
+	ok, errs := ConvertAllLenient(src, userEmail)
+
+	if want := []string{src[0].Email, src[2].Email}; !reflect.DeepEqual(ok, want) {
+		t.Errorf("ok: got %v, want %v", ok, want)
+	}
+	if len(errs) != 2 || errs[0].Index != 1 || errs[1].Index != 3 {
+		t.Fatalf("errs: got %v, want indices [1 3]", errs)
+	}
+	for _, e := range errs {
+		if !errors.Is(e, errNoEmail) {
+			t.Errorf("got %v, want errNoEmail", e)
+		}
+	}
+
+	ok, errs = ConvertAllLenient(genUsers(2), userEmail)
+	if len(ok) != 2 || errs != nil {
+		t.Errorf("all ok: got ok=%v errs=%v", ok, errs)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	var got []string
+	ForEach(demoUsers[:3], func(i int, u user) {
+		got = append(got, strconv.Itoa(i)+":"+u.Name)
+	})
+	if want := []string{"0:Alice", "1:Bob", "2:Carol"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestForEachPtr(t *testing.T) {
+	alice, bob := &user{Name: "Alice"}, &user{Name: "Bob"}
+	src := []*user{nil, alice, nil, bob}
+
+	var idx []int
+	var names []string
+	ForEachPtr(src, func(i int, u *user) {
+		idx = append(idx, i)
+		names = append(names, u.Name) // nil が渡れば panic する
+	})
+	if !reflect.DeepEqual(idx, []int{1, 3}) || !reflect.DeepEqual(names, []string{"Alice", "Bob"}) {
+		t.Errorf("ForEachPtr: got idx=%v names=%v", idx, names)
+	}
+
+	var nils int
+	idx = nil
+	ForEachPtrIncludeNil(src, func(i int, u *user) {
+		idx = append(idx, i)
+		if u == nil {
+			nils++
+		}
+	})
+	if !reflect.DeepEqual(idx, []int{0, 1, 2, 3}) || nils != 2 {
+		t.Errorf("ForEachPtrIncludeNil: got idx=%v nils=%d", idx, nils)
+	}
+}
@@ -0,0 +1,94 @@
+package slicepat
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTreeCycle は BuildTree の入力の親子関係が循環しているときに返る。
+var ErrTreeCycle = errors.New("slicepat: parent references form a cycle")
+
+// Node は BuildTree が組み立てる木の節。
+type Node[T any] struct {
+	Value    T
+	Children []*Node[T]
+}
+
+// BuildTree はフラットなスライスから親参照をたどって森（根のスライス）を組み立てる。
+// parentID が false を返す要素、または存在しない親を指す要素は根になる。
+// 根・子どちらも src での順に並ぶ。ID が重複している場合と、親子関係が循環している場合
+// （ErrTreeCycle）はエラーを返す。上司を参照するユーザーの階層化などに使う。
+func BuildTree[K comparable, T any](src []T, id func(T) K, parentID func(T) (K, bool)) ([]*Node[T], error) {
+	nodes := make(map[K]*Node[T], len(src))
+	ordered := make([]*Node[T], len(src))
+	for i, v := range src {
+		k := id(v)
+		if _, dup := nodes[k]; dup {
+			return nil, fmt.Errorf("slicepat: duplicate id %v", k)
+		}
+		n := &Node[T]{Value: v}
+		nodes[k] = n
+		ordered[i] = n
+	}
+
+	roots := []*Node[T]{}
+	for i, v := range src {
+		n := ordered[i]
+		pk, ok := parentID(v)
+		parent, found := nodes[pk]
+		if !ok || !found {
+			roots = append(roots, n)
+			continue
+		}
+		parent.Children = append(parent.Children, n)
+	}
+
+	// 根から辿れない節があれば、それは循環の中にいる
+	reachable := 0
+	stack := append([]*Node[T](nil), roots...)
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		reachable++
+		stack = append(stack, n.Children...)
+	}
+	if reachable != len(src) {
+		return nil, ErrTreeCycle
+	}
+	return roots, nil
+}
+
+// TraversalOrder は FlattenTree の走査順。
+type TraversalOrder int
+
+const (
+	// PreOrder は深さ優先の行きがけ順（親のすぐ後にその子孫が続く）。
+	PreOrder TraversalOrder = iota
+	// BreadthFirst は幅優先順（浅い階層から順に並ぶ）。
+	BreadthFirst
+)
+
+// FlattenTree は BuildTree で組み立てた森を order の順に走査し、値をフラットなスライスで返す。
+func FlattenTree[T any](roots []*Node[T], order TraversalOrder) []T {
+	out := []T{}
+	switch order {
+	case BreadthFirst:
+		queue := append([]*Node[T](nil), roots...)
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			out = append(out, n.Value)
+			queue = append(queue, n.Children...)
+		}
+	default:
+		var walk func([]*Node[T])
+		walk = func(nodes []*Node[T]) {
+			for _, n := range nodes {
+				out = append(out, n.Value)
+				walk(n.Children)
+			}
+		}
+		walk(roots)
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package slicepat
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type employee struct {
+	ID        uint
+	Name      string
+	ManagerID uint // 0 は上司なし
+}
+
+func employeeID(e employee) uint { return e.ID }
+func employeeManager(e employee) (uint, bool) {
+	return e.ManagerID, e.ManagerID != 0
+}
+
+// 木の形を "名前(子,子)" 形式の文字列にする
+func treeString(nodes []*Node[employee]) string {
+	s := ""
+	for i, n := range nodes {
+		if i > 0 {
+			s += ","
+		}
+		s += n.Value.Name
+		if len(n.Children) > 0 {
+			s += "(" + treeString(n.Children) + ")"
+		}
+	}
+	return s
+}
+
+func TestBuildTree(t *testing.T) {
+	src := []employee{
+		{ID: 3, Name: "Carol", ManagerID: 1},
+		{ID: 1, Name: "Alice"},
+		{ID: 4, Name: "Dave", ManagerID: 3},
+		{ID: 2, Name: "Bob", ManagerID: 1},
+		{ID: 5, Name: "Eve"},
+		{ID: 6, Name: "Frank", ManagerID: 99}, // 存在しない上司 → 根
+	}
+	roots, err := BuildTree(src, employeeID, employeeManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := treeString(roots), "Alice(Carol(Dave),Bob),Eve,Frank"; got != want {
+		t.Errorf("tree = %s, want %s", got, want)
+	}
+}
+
+func TestBuildTree_Errors(t *testing.T) {
+	cyclic := []employee{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob", ManagerID: 3},
+		{ID: 3, Name: "Carol", ManagerID: 2},
+	}
+	if _, err := BuildTree(cyclic, employeeID, employeeManager); !errors.Is(err, ErrTreeCycle) {
+		t.Errorf("cycle: err = %v, want %v", err, ErrTreeCycle)
+	}
+
+	self := []employee{{ID: 1, Name: "Alice", ManagerID: 1}}
+	if _, err := BuildTree(self, employeeID, employeeManager); !errors.Is(err, ErrTreeCycle) {
+		t.Errorf("self parent: err = %v, want %v", err, ErrTreeCycle)
+	}
+
+	dup := []employee{{ID: 1, Name: "Alice"}, {ID: 1, Name: "Bob"}}
+	if _, err := BuildTree(dup, employeeID, employeeManager); err == nil {
+		t.Error("duplicate id: expected error")
+	}
+}
+
+func TestFlattenTree(t *testing.T) {
+	src := []employee{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob", ManagerID: 1},
+		{ID: 3, Name: "Carol", ManagerID: 1},
+		{ID: 4, Name: "Dave", ManagerID: 2},
+		{ID: 5, Name: "Eve"},
+		{ID: 6, Name: "Frank", ManagerID: 5},
+	}
+	roots, err := BuildTree(src, employeeID, employeeManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(es []employee) []string {
+		out := make([]string, len(es))
+		for i, e := range es {
+			out[i] = e.Name
+		}
+		return out
+	}
+	tests := []struct {
+		order TraversalOrder
+		want  []string
+	}{
+		{PreOrder, []string{"Alice", "Bob", "Dave", "Carol", "Eve", "Frank"}},
+		{BreadthFirst, []string{"Alice", "Eve", "Bob", "Carol", "Frank", "Dave"}},
+	}
+	for _, tt := range tests {
+		if got := names(FlattenTree(roots, tt.order)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("order %d: got %v, want %v", tt.order, got, tt.want)
+		}
+	}
+}